@@ -0,0 +1,313 @@
+// Package api holds the JSON documents of the versioned API. They are kept apart
+// from the objects stored in Firestore, so that renaming a Go field does not
+// change what the clients receive.
+package api
+
+import (
+	"fmt"
+	"refugio/objects"
+	"refugio/retention"
+	"refugio/stats"
+	"time"
+)
+
+// Version is the path prefix of the versioned routes.
+const Version = "/v1"
+
+// Pessoa is a person found in one of the shelter spreadsheets.
+type Pessoa struct {
+	Id         string    `json:"id" doc:"Id do registro, o mesmo do histórico de alterações"`
+	Nome       string    `json:"nome" doc:"Nome como aparece na planilha"`
+	Abrigo     string    `json:"abrigo" doc:"Abrigo onde a pessoa está"`
+	Idade      string    `json:"idade" doc:"Idade como aparece na planilha, pode estar vazia"`
+	Observacao string    `json:"observacao" doc:"Observações da planilha, pode estar vazia"`
+	SheetId    string    `json:"sheet_id" doc:"Id da planilha de origem, vazio quando desconhecido"`
+	URL        string    `json:"url" doc:"Link da planilha de origem, vazio quando desconhecido"`
+	Cidade     string    `json:"cidade" doc:"Cidade da planilha de origem, vazia quando desconhecida"`
+	UpdatedAt  time.Time `json:"updated_at" doc:"Quando o registro foi gravado pela última vez"`
+	// LastConfirmedAt is after UpdatedAt when a later scrape found the record unchanged
+	LastConfirmedAt time.Time `json:"last_confirmed_at" doc:"Quando o registro foi encontrado na planilha de origem pela última vez"`
+	Stale           bool      `json:"stale" doc:"O registro não é encontrado na planilha de origem há dias e pode estar desatualizado"`
+	Departed        bool      `json:"departed" doc:"O registro deixou de aparecer na planilha de origem"`
+}
+
+// Revision is one write to a record.
+type Revision struct {
+	Action    string    `json:"action" doc:"insert, update, departed, delete ou takedown"`
+	Actor     string    `json:"actor" doc:"Quem fez a alteração: scrape:<execução>, import:<arquivo> ou key:<id da chave>"`
+	Before    *Pessoa   `json:"before" doc:"O registro antes da alteração, nulo quando ele foi criado"`
+	After     *Pessoa   `json:"after" doc:"O registro depois da alteração, nulo quando ele foi removido"`
+	Changes   []string  `json:"changes" doc:"Os campos alterados, com o valor anterior e o novo"`
+	Timestamp time.Time `json:"timestamp"`
+	// TakedownId is set for the takedown revisions, which hold no data of the record
+	TakedownId string `json:"takedown_id,omitempty" doc:"Id do pedido de remoção aplicado, nas revisões do tipo takedown"`
+}
+
+type History struct {
+	Id        string     `json:"id"`
+	Revisions []Revision `json:"revisions" doc:"Alterações do registro, da mais antiga à mais recente"`
+}
+
+// TakedownRequest asks for a record to be removed or to have its free text cleared or
+// corrected.
+type TakedownRequest struct {
+	Id         string              `json:"id" doc:"Id do registro, o campo id de /v1/pessoa"`
+	Kind       string              `json:"kind" doc:"remove para remover o registro, redact para apagar a idade e as observações, correct para corrigi-las"`
+	Correction *TakedownCorrection `json:"correction,omitempty" doc:"A idade e as observações corretas, obrigatórias no tipo correct e recusadas nos outros"`
+	Reason     string              `json:"reason" doc:"Motivo do pedido"`
+	Contact    string              `json:"contact,omitempty" doc:"Como falar com quem fez o pedido, opcional"`
+}
+
+// TakedownCorrection holds the fields a correct takedown sets, an empty one is cleared.
+type TakedownCorrection struct {
+	Idade      string `json:"idade" doc:"Idade correta, vazia para apagá-la"`
+	Observacao string `json:"observacao" doc:"Observações corretas, vazias para apagá-las"`
+}
+
+type TakedownCreated struct {
+	Id     string `json:"id" doc:"Id do pedido"`
+	Status string `json:"status" doc:"pending até que o pedido seja analisado"`
+}
+
+// Takedown is a takedown request as the admins see it.
+type Takedown struct {
+	Id         string              `json:"id"`
+	PessoaId   string              `json:"pessoa_id"`
+	Kind       string              `json:"kind"`
+	Correction *TakedownCorrection `json:"correction,omitempty"`
+	Reason     string              `json:"reason"`
+	Contact    string              `json:"contact"`
+	Status     string              `json:"status"`
+	CreatedAt  time.Time           `json:"created_at"`
+	DecidedAt  *time.Time          `json:"decided_at"`
+	Events     []TakedownEvent     `json:"events"`
+}
+
+type TakedownEvent struct {
+	Action    string    `json:"action"`
+	Actor     string    `json:"actor"`
+	Note      string    `json:"note,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type Source struct {
+	Nome        string     `json:"nome"`
+	SheetId     string     `json:"sheet_id"`
+	URL         string     `json:"url"`
+	Observacao  string     `json:"observacao"`
+	Sheets      []string   `json:"sheets" doc:"Abas lidas da planilha"`
+	Organizacao string     `json:"organizacao" doc:"Organização responsável pela planilha"`
+	Contato     string     `json:"contato" doc:"Contato da organização responsável"`
+	Cidade      string     `json:"cidade" doc:"Cidade dos abrigos da planilha"`
+	Regiao      string     `json:"regiao" doc:"Região da cidade"`
+	ModifiedAt  *time.Time `json:"modified_at" doc:"Última alteração da planilha segundo o Google Drive, nula quando não é conhecida"`
+	ScrapedAt   *time.Time `json:"scraped_at" doc:"Último scrape que leu a planilha, nulo quando ainda não foi lida"`
+	// Status and the fields after it are only tracked for the configured spreadsheets
+	Status        string     `json:"status" doc:"ok, failing quando os últimos scrapes não conseguiram ler a planilha, disabled quando ela deixou de ser lida depois de falhar seguidamente, ou unknown. A lista pode estar desatualizada quando não está ok"`
+	Failures      int        `json:"failures" doc:"Scrapes seguidos que não conseguiram ler a planilha"`
+	LastErrorAt   *time.Time `json:"last_error_at" doc:"Última vez em que a leitura da planilha falhou, nula quando nunca falhou"`
+	LastSuccessAt *time.Time `json:"last_success_at" doc:"Última vez em que a planilha foi lida, nula quando ainda não foi"`
+	DisabledAt    *time.Time `json:"disabled_at" doc:"Quando a planilha deixou de ser lida, nula quando ela está ativa"`
+}
+
+type Count struct {
+	Total     int            `json:"total_records" doc:"Quantidade de pessoas registradas"`
+	Departed  int            `json:"departed" doc:"Registros que deixaram de aparecer na planilha de origem"`
+	BySource  map[string]int `json:"by_source" doc:"Quantidade por id de planilha"`
+	ByAbrigo  map[string]int `json:"by_abrigo" doc:"Quantidade por abrigo"`
+	UpdatedAt *time.Time     `json:"updated_at" doc:"Última atualização da contagem, nula quando ainda não há contagem"`
+}
+
+type MostRecent struct {
+	Timestamp *time.Time `json:"timestamp" doc:"Data do registro mais recente, nula quando não há registros"`
+}
+
+type Stats struct {
+	From       string     `json:"from" doc:"Primeiro dia incluído, vazio quando não informado"`
+	To         string     `json:"to" doc:"Último dia incluído, vazio quando não informado"`
+	GroupBy    []string   `json:"group_by" doc:"Dimensões do agrupamento: day, source, abrigo ou cidade"`
+	Total      int        `json:"total" doc:"Pessoas registradas no período"`
+	Departed   int        `json:"departed" doc:"Das pessoas do período, as que deixaram de aparecer na planilha de origem"`
+	ComputedAt *time.Time `json:"computed_at" doc:"Quando as estatísticas foram calculadas, nula quando ainda não foram"`
+	Series     []StatsRow `json:"series"`
+}
+
+// StatsRow has only the dimensions the series is grouped by.
+type StatsRow struct {
+	Day      string `json:"day,omitempty" doc:"Dia em que os registros foram gravados, no horário de Brasília"`
+	SheetId  string `json:"sheet_id,omitempty"`
+	Abrigo   string `json:"abrigo,omitempty"`
+	Cidade   string `json:"cidade,omitempty"`
+	Total    int    `json:"total"`
+	Departed int    `json:"departed"`
+}
+
+func NewStats(query stats.Query, days []*objects.StatsDay) Stats {
+	result := Stats{From: query.From, To: query.To, GroupBy: query.GroupBy, Series: []StatsRow{}}
+	for _, day := range days {
+		if result.ComputedAt == nil || day.ComputedAt.After(*result.ComputedAt) {
+			computedAt := day.ComputedAt
+			result.ComputedAt = &computedAt
+		}
+	}
+	for _, row := range stats.Aggregate(days, query.GroupBy) {
+		result.Total += row.Total
+		result.Departed += row.Departed
+		result.Series = append(result.Series, StatsRow(row))
+	}
+	return result
+}
+
+func NewPessoa(p *objects.PessoaResult) Pessoa {
+	// The web server does not start with an invalid policy
+	policy, err := retention.CurrentPolicy()
+	pessoa := Pessoa{
+		UpdatedAt:       p.Updated(),
+		LastConfirmedAt: p.LastConfirmed(),
+		Stale:           err == nil && policy.IsStale(p, time.Now()),
+		Cidade:          p.Cidade,
+		Departed:        p.Departed,
+	}
+	if p.Pessoa != nil {
+		pessoa.Id = p.AggregateKey()
+		pessoa.Nome = p.Nome
+		pessoa.Abrigo = p.Abrigo
+		pessoa.Idade = p.Idade
+		pessoa.Observacao = p.Observacao
+	}
+	if p.SheetId != nil {
+		pessoa.SheetId = *p.SheetId
+	}
+	if p.URL != nil {
+		pessoa.URL = *p.URL
+	}
+	return pessoa
+}
+
+func NewPessoas(results []*objects.PessoaResult) []Pessoa {
+	pessoas := make([]Pessoa, 0, len(results))
+	for _, p := range results {
+		pessoas = append(pessoas, NewPessoa(p))
+	}
+	return pessoas
+}
+
+func NewHistory(id string, revisions []*objects.Revision) History {
+	history := History{Id: id, Revisions: make([]Revision, 0, len(revisions))}
+	for _, r := range revisions {
+		revision := Revision{Action: r.Action, Actor: r.Actor.String(), Timestamp: r.Timestamp, TakedownId: r.TakedownId}
+		var before, after Pessoa
+		if r.Before != nil {
+			before = NewPessoa(r.Before)
+			revision.Before = &before
+		}
+		if r.After != nil {
+			after = NewPessoa(r.After)
+			revision.After = &after
+		}
+		revision.Changes = changes(before, after, revision.Before != nil && revision.After != nil)
+		history.Revisions = append(history.Revisions, revision)
+	}
+	return history
+}
+
+// changes lists the fields that differ, a missing side of the revision being empty.
+func changes(before Pessoa, after Pessoa, bothSides bool) []string {
+	changes := []string{}
+	compare := func(field string, before string, after string) {
+		if before != after {
+			changes = append(changes, fmt.Sprintf("%s: %q -> %q", field, before, after))
+		}
+	}
+	compare("nome", before.Nome, after.Nome)
+	compare("abrigo", before.Abrigo, after.Abrigo)
+	compare("idade", before.Idade, after.Idade)
+	compare("observacao", before.Observacao, after.Observacao)
+	compare("sheet_id", before.SheetId, after.SheetId)
+	compare("url", before.URL, after.URL)
+	compare("cidade", before.Cidade, after.Cidade)
+	if bothSides && before.Departed != after.Departed {
+		changes = append(changes, fmt.Sprintf("departed: %t -> %t", before.Departed, after.Departed))
+	}
+	return changes
+}
+
+func NewTakedown(t *objects.Takedown) Takedown {
+	takedown := Takedown{
+		Id:        t.Id,
+		PessoaId:  t.Key,
+		Kind:      t.Kind,
+		Reason:    t.Reason,
+		Contact:   t.Contact,
+		Status:    t.Status,
+		CreatedAt: t.CreatedAt,
+		DecidedAt: t.DecidedAt,
+		Events:    make([]TakedownEvent, 0, len(t.Events)),
+	}
+	if t.Correction != nil {
+		takedown.Correction = &TakedownCorrection{Idade: t.Correction.Idade, Observacao: t.Correction.Observacao}
+	}
+	for _, event := range t.Events {
+		takedown.Events = append(takedown.Events, TakedownEvent{Action: event.Action, Actor: event.Actor.String(), Note: event.Note, Timestamp: event.Timestamp})
+	}
+	return takedown
+}
+
+func NewTakedowns(results []*objects.Takedown) []Takedown {
+	takedowns := make([]Takedown, 0, len(results))
+	for _, t := range results {
+		takedowns = append(takedowns, NewTakedown(t))
+	}
+	return takedowns
+}
+
+func NewCount(counters *objects.PessoaCounters) Count {
+	count := Count{BySource: map[string]int{}, ByAbrigo: map[string]int{}}
+	if counters == nil {
+		return count
+	}
+	count.Total = counters.Total
+	count.Departed = counters.Departed
+	count.UpdatedAt = &counters.UpdatedAt
+	// Counters go down to zero instead of disappearing
+	for key, n := range counters.BySource {
+		if n != 0 {
+			count.BySource[key] = n
+		}
+	}
+	for key, n := range counters.ByAbrigo {
+		if n != 0 {
+			count.ByAbrigo[key] = n
+		}
+	}
+	return count
+}
+
+func NewSources(results []*objects.Source) []Source {
+	sources := make([]Source, 0, len(results))
+	for _, s := range results {
+		sheets := s.Sheets
+		if sheets == nil {
+			sheets = []string{}
+		}
+		sources = append(sources, Source{
+			Nome:          s.Nome,
+			SheetId:       s.SheetId,
+			URL:           s.URL,
+			Observacao:    s.Observacao,
+			Sheets:        sheets,
+			Organizacao:   s.Organizacao,
+			Contato:       s.Contato,
+			Cidade:        s.Cidade,
+			Regiao:        s.Regiao,
+			ModifiedAt:    s.ModifiedAt,
+			ScrapedAt:     s.ScrapedAt,
+			Status:        s.Health.Status(),
+			Failures:      s.Health.Failures,
+			LastErrorAt:   s.Health.LastErrorAt,
+			LastSuccessAt: s.Health.LastSuccessAt,
+			DisabledAt:    s.Health.DisabledAt,
+		})
+	}
+	return sources
+}
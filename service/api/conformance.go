@@ -0,0 +1,210 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// CheckResult is the outcome of calling one operation of the document.
+type CheckResult struct {
+	Method   string
+	Path     string
+	Status   int
+	Problems []string
+}
+
+// Check calls every GET operation of the document on a running server and
+// validates the responses against their schemas. params fills the query
+// parameters, operations missing a required one are reported as problems.
+func (d *Document) Check(ctx context.Context, client *http.Client, baseURL string, key string, params map[string]string) []CheckResult {
+	paths := make([]string, 0, len(d.Paths))
+	for path := range d.Paths {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+
+	var results []CheckResult
+	for _, path := range paths {
+		op, ok := d.Paths[path]["get"]
+		if !ok {
+			continue
+		}
+		result := CheckResult{Method: http.MethodGet, Path: path}
+		result.Status, result.Problems = d.checkOperation(ctx, client, baseURL+path, key, op, params)
+		results = append(results, result)
+	}
+	return results
+}
+
+func (d *Document) checkOperation(ctx context.Context, client *http.Client, rawURL string, key string, op *Operation, params map[string]string) (int, []string) {
+	query := url.Values{}
+	for _, param := range op.Parameters {
+		value, ok := params[param.Name]
+		if !ok {
+			if param.Required {
+				return 0, []string{fmt.Sprintf("no value for the required parameter %q", param.Name)}
+			}
+			continue
+		}
+		query.Set(param.Name, value)
+	}
+	if len(query) > 0 {
+		rawURL += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return 0, []string{err.Error()}
+	}
+	if key != "" {
+		req.Header.Set("Authorization", key)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, []string{err.Error()}
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, []string{err.Error()}
+	}
+	return resp.StatusCode, d.ValidateResponse(op, resp.StatusCode, resp.Header.Get("Content-Type"), body)
+}
+
+// ValidateResponse checks that the operation documents the status and content type of
+// a response and that its body matches the schema, returning one problem per mismatch.
+func (d *Document) ValidateResponse(op *Operation, status int, contentType string, body []byte) []string {
+	response, ok := op.Responses[strconv.Itoa(status)]
+	if !ok {
+		response, ok = op.Responses["default"]
+	}
+	if !ok {
+		return []string{fmt.Sprintf("status %d is not documented", status)}
+	}
+	if len(response.Content) == 0 {
+		return nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	media, ok := response.Content[mediaType]
+	if !ok {
+		return []string{fmt.Sprintf("content type %q is not documented", mediaType)}
+	}
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return []string{fmt.Sprintf("invalid JSON: %v", err)}
+	}
+	return d.Validate(media.Schema, value)
+}
+
+// Validate checks a value decoded from JSON against a schema of the document,
+// returning one problem per mismatch.
+func (d *Document) Validate(schema *Schema, value any) []string {
+	var problems []string
+	d.validate("$", schema, value, &problems)
+	return problems
+}
+
+func (d *Document) validate(path string, schema *Schema, value any, problems *[]string) {
+	fail := func(format string, args ...any) {
+		*problems = append(*problems, path+": "+fmt.Sprintf(format, args...))
+	}
+
+	if schema.Ref != "" {
+		resolved, ok := d.Components.Schemas[refName(schema.Ref)]
+		if !ok {
+			fail("unknown schema %s", schema.Ref)
+			return
+		}
+		d.validate(path, resolved, value, problems)
+		return
+	}
+	if value == nil {
+		if !schema.Nullable {
+			fail("null is not allowed")
+		}
+		return
+	}
+	for _, sub := range schema.AllOf {
+		d.validate(path, sub, value, problems)
+	}
+
+	switch schema.Type {
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			fail("expected a string, got %T", value)
+			return
+		}
+		if schema.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, s); err != nil {
+				fail("invalid date-time %q", s)
+			}
+		}
+		if utf8.RuneCountInString(s) < schema.MinLength {
+			fail("shorter than %d characters", schema.MinLength)
+		}
+		if schema.Pattern != "" && !regexp.MustCompile(schema.Pattern).MatchString(s) {
+			fail("does not match %s", schema.Pattern)
+		}
+	case "integer", "number":
+		n, ok := value.(float64)
+		if !ok {
+			fail("expected a %s, got %T", schema.Type, value)
+			return
+		}
+		if schema.Type == "integer" && n != math.Trunc(n) {
+			fail("expected an integer, got %v", n)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			fail("expected a boolean, got %T", value)
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			fail("expected an array, got %T", value)
+			return
+		}
+		for i, item := range items {
+			d.validate(fmt.Sprintf("%s[%d]", path, i), schema.Items, item, problems)
+		}
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			fail("expected an object, got %T", value)
+			return
+		}
+		for _, name := range schema.Required {
+			if _, ok := object[name]; !ok {
+				fail("missing required property %q", name)
+			}
+		}
+		for name, property := range object {
+			propertyPath := path + "." + name
+			if propertySchema, ok := schema.Properties[name]; ok {
+				d.validate(propertyPath, propertySchema, property, problems)
+				continue
+			}
+			switch additional := schema.AdditionalProperties.(type) {
+			case *Schema:
+				d.validate(propertyPath, additional, property, problems)
+			case bool:
+				if !additional {
+					fail("undocumented property %q", name)
+				}
+			}
+		}
+	}
+}
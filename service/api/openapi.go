@@ -0,0 +1,279 @@
+package api
+
+import (
+	"net/http"
+	"reflect"
+	"refugio/web"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Document is the part of OpenAPI 3.0 used to describe this API.
+type Document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       Info                             `json:"info"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components Components                       `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes"`
+}
+
+type SecurityScheme struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+	In   string `json:"in"`
+}
+
+type Operation struct {
+	OperationId string                `json:"operationId"`
+	Summary     string                `json:"summary"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	MinLength            int                `json:"minLength,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties any                `json:"additionalProperties,omitempty"`
+}
+
+const (
+	jsonContentType = "application/json"
+	apiKeyScheme    = "ApiKey"
+)
+
+// NomePattern is what a searched name may contain.
+const NomePattern = `^[\p{L}\s0-9]{3,}$`
+
+// Spec returns the OpenAPI document of the versioned routes. It is generated
+// from the response types, so it cannot drift from what the handlers encode.
+var Spec = sync.OnceValue(func() *Document {
+	g := &generator{schemas: map[string]*Schema{}}
+
+	errors := map[int]string{
+		http.StatusForbidden:           "Chave de API ausente, inválida ou sem o escopo necessário",
+		http.StatusTooManyRequests:     "Limite de requisições atingido, veja o cabeçalho Retry-After",
+		http.StatusInternalServerError: "Erro interno",
+		http.StatusServiceUnavailable:  "Um serviço usado pela rota está indisponível",
+	}
+	nome := &Parameter{
+		Name:        "nome",
+		In:          "query",
+		Description: "Nome ou parte do nome, com pelo menos 3 caracteres",
+		Required:    true,
+		Schema:      &Schema{Type: "string", MinLength: 3, Pattern: NomePattern},
+	}
+
+	paths := map[string]map[string]*Operation{
+		Version + "/pessoa": {
+			"get": g.operation("searchPessoas", "Busca pessoas pelo nome", []Pessoa{},
+				withErrors(errors, map[int]string{http.StatusBadRequest: "Parâmetro nome ausente ou inválido"}), nome,
+				&Parameter{Name: "include_hidden", In: "query", Description: "Inclui os registros que não são encontrados na planilha de origem há mais tempo que o período de retenção, só com uma chave de escopo admin", Schema: &Schema{Type: "boolean"}}),
+		},
+		Version + "/pessoa/count": {
+			"get": g.operation("countPessoas", "Quantidade de pessoas registradas", Count{}, errors),
+		},
+		Version + "/pessoa/most_recent": {
+			"get": g.operation("mostRecentPessoa", "Data do registro mais recente", MostRecent{}, errors),
+		},
+		Version + "/stats": {
+			"get": g.operation("getStats", "Pessoas registradas por dia, planilha, abrigo e cidade", Stats{},
+				withErrors(errors, map[int]string{http.StatusBadRequest: "Datas ou agrupamento inválidos"}),
+				&Parameter{Name: "from", In: "query", Description: "Primeiro dia incluído (AAAA-MM-DD)", Schema: &Schema{Type: "string", Format: "date"}},
+				&Parameter{Name: "to", In: "query", Description: "Último dia incluído (AAAA-MM-DD)", Schema: &Schema{Type: "string", Format: "date"}},
+				&Parameter{Name: "group_by", In: "query", Description: "Dimensões separadas por vírgula: day (padrão), source, abrigo, cidade", Schema: &Schema{Type: "string"}},
+			),
+		},
+		Version + "/takedowns": {
+			"post": g.create(g.operation("requestTakedown", "Pede a remoção de um registro ou das suas observações", TakedownCreated{},
+				withErrors(errors, map[int]string{
+					http.StatusBadRequest: "Pedido incompleto ou inválido",
+					http.StatusNotFound:   "Não há registro com o id",
+				})), TakedownRequest{}),
+		},
+		Version + "/sources": {
+			"get": g.operation("listSources", "Planilhas de onde os registros são lidos", []Source{}, errors),
+		},
+	}
+
+	return &Document{
+		OpenAPI: "3.0.3",
+		Info: Info{
+			Title:       "Refugio",
+			Description: "Busca de pessoas nas planilhas dos abrigos.",
+			Version:     strings.TrimPrefix(Version, "/"),
+		},
+		Paths: paths,
+		Components: Components{
+			Schemas: g.schemas,
+			SecuritySchemes: map[string]*SecurityScheme{
+				apiKeyScheme: {Type: "apiKey", Name: "Authorization", In: "header"},
+			},
+		},
+	}
+})
+
+func withErrors(errors map[int]string, more map[int]string) map[int]string {
+	merged := map[int]string{}
+	for status, description := range errors {
+		merged[status] = description
+	}
+	for status, description := range more {
+		merged[status] = description
+	}
+	return merged
+}
+
+// generator builds schemas from Go types, registering named structs as components.
+type generator struct {
+	schemas map[string]*Schema
+}
+
+func (g *generator) operation(id string, summary string, response any, errors map[int]string, parameters ...*Parameter) *Operation {
+	op := &Operation{
+		OperationId: id,
+		Summary:     summary,
+		Parameters:  parameters,
+		Responses: map[string]*Response{
+			"200": {
+				Description: "OK",
+				Content:     map[string]*MediaType{jsonContentType: {Schema: g.schema(reflect.TypeOf(response))}},
+			},
+		},
+		Security: []map[string][]string{{apiKeyScheme: {}}},
+	}
+	errorSchema := g.schema(reflect.TypeOf(web.ErrorResponse{}))
+	for status, description := range errors {
+		op.Responses[strconv.Itoa(status)] = &Response{
+			Description: description,
+			Content:     map[string]*MediaType{jsonContentType: {Schema: errorSchema}},
+		}
+	}
+	return op
+}
+
+// create turns the operation into one that takes the body and answers 201.
+func (g *generator) create(op *Operation, body any) *Operation {
+	op.RequestBody = &RequestBody{
+		Required: true,
+		Content:  map[string]*MediaType{jsonContentType: {Schema: g.schema(reflect.TypeOf(body))}},
+	}
+	op.Responses[strconv.Itoa(http.StatusCreated)] = op.Responses["200"]
+	op.Responses[strconv.Itoa(http.StatusCreated)].Description = "Criado"
+	delete(op.Responses, "200")
+	return op
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func (g *generator) schema(t reflect.Type) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t.Kind() == reflect.Pointer:
+		schema := g.schema(t.Elem())
+		if schema.Ref != "" {
+			// A $ref can't have siblings in OpenAPI 3.0
+			return &Schema{Nullable: true, AllOf: []*Schema{schema}}
+		}
+		schema.Nullable = true
+		return schema
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		return g.structSchema(t)
+	}
+	return &Schema{}
+}
+
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	ref := &Schema{Ref: "#/components/schemas/" + t.Name()}
+	if _, ok := g.schemas[t.Name()]; ok {
+		return ref
+	}
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}, AdditionalProperties: false}
+	g.schemas[t.Name()] = schema
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		property := g.schema(field.Type)
+		// Descriptions next to a $ref are ignored in OpenAPI 3.0
+		if description := field.Tag.Get("doc"); description != "" && property.Ref == "" {
+			property.Description = description
+		}
+		schema.Properties[name] = property
+		if !strings.Contains(options, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return ref
+}
+
+func refName(ref string) string {
+	return strings.TrimPrefix(ref, "#/components/schemas/")
+}
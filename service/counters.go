@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"refugio/objects"
+	"refugio/repository"
+	"refugio/scheduler"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var countersCmd = &cobra.Command{
+	Use:   "counters",
+	Short: "Show or rebuild the record counters",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		counters, err := repository.FetchPessoaCounters(cmd.Context())
+		if err != nil {
+			return err
+		}
+		if counters == nil {
+			return fmt.Errorf("no counters stored, run counters rebuild")
+		}
+		printCounters(counters)
+		return nil
+	},
+}
+
+var countersRebuildCmd = &cobra.Command{
+	Use:   "rebuild",
+	Short: "Count every stored record and replace the counters",
+	Long:  "Count every stored record and replace the counters. It holds the scrape lock, so that no scrape changes the records while they are counted.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var counters *objects.PessoaCounters
+		err := scheduler.WithLock(cmd.Context(), func(ctx context.Context) error {
+			var err error
+			counters, err = repository.RebuildPessoaCounters(ctx)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		printCounters(counters)
+		return nil
+	},
+}
+
+func printCounters(counters *objects.PessoaCounters) {
+	fmt.Printf("Total: %d, departed: %d, updated at %s\n", counters.Total, counters.Departed, counters.UpdatedAt.Format(time.DateTime))
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for _, group := range []struct {
+		title  string
+		counts map[string]int
+	}{{"SOURCE", counters.BySource}, {"ABRIGO", counters.ByAbrigo}} {
+		fmt.Fprintf(tw, "\n%s\tRECORDS\n", group.title)
+		keys := make([]string, 0, len(group.counts))
+		for key, n := range group.counts {
+			if n != 0 {
+				keys = append(keys, key)
+			}
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			fmt.Fprintf(tw, "%s\t%d\n", key, group.counts[key])
+		}
+	}
+	tw.Flush()
+}
+
+func init() {
+	countersCmd.AddCommand(countersRebuildCmd)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"refugio/export"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the stored records as CSV, XLSX or JSON lines",
+	Long:  "Export the stored records selected by the filters, a page at a time. The personal information in the free text fields is redacted, as in the /export endpoint.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		values := url.Values{}
+		for _, name := range []string{"abrigo", "cidade", "source", "from", "to"} {
+			value, _ := cmd.Flags().GetString(name)
+			values.Set(name, value)
+		}
+		filter, err := export.ParseFilter(values)
+		if err != nil {
+			return err
+		}
+		format, _ := cmd.Flags().GetString("format")
+		format, err = export.ParseFormat(format)
+		if err != nil {
+			return err
+		}
+
+		output, _ := cmd.Flags().GetString("output")
+		out := os.Stdout
+		if output != "" {
+			out, err = os.Create(output)
+			if err != nil {
+				return err
+			}
+		}
+		written, err := export.Export(cmd.Context(), out, format, filter, nil)
+		if output != "" {
+			// A failed close can lose the end of the file, so it fails the export too
+			err = errors.Join(err, out.Close())
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Exported %d records\n", written)
+		return nil
+	},
+}
+
+func init() {
+	exportCmd.Flags().String("format", export.CSV, "Format of the file: "+strings.Join(export.Formats, ", "))
+	exportCmd.Flags().StringP("output", "o", "", "File to write, the standard output when empty")
+	exportCmd.Flags().String("abrigo", "", "Only the records of this abrigo")
+	exportCmd.Flags().String("cidade", "", "Only the records of this city")
+	exportCmd.Flags().String("source", "", "Only the records of this spreadsheet id")
+	exportCmd.Flags().String("from", "", "First day the records were first stored, as 2006-01-02")
+	exportCmd.Flags().String("to", "", "Last day the records were first stored, as 2006-01-02")
+}
@@ -0,0 +1,227 @@
+// Package export writes the stored records as CSV, XLSX or JSON lines for the
+// partner agencies. The records are read and written a page at a time, and the
+// personal information in their free text fields is redacted.
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"refugio/api"
+	"refugio/objects"
+	"refugio/repository"
+	"refugio/stats"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/* Formats */
+const (
+	CSV   = "csv"
+	XLSX  = "xlsx"
+	JSONL = "jsonl"
+)
+
+var Formats = []string{CSV, XLSX, JSONL}
+
+// PageSize is how many records are read from Firestore at a time.
+const PageSize = 500
+
+// Columns are the fields of api.Pessoa, in the order the CSV and XLSX files have them.
+var Columns = []string{"nome", "abrigo", "idade", "observacao", "sheet_id", "url", "cidade", "updated_at", "departed"}
+
+func ContentType(format string) string {
+	switch format {
+	case XLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case JSONL:
+		return "application/jsonl; charset=utf-8"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// Writer writes the records in one of the formats. Flush sends what is buffered to
+// the underlying writer and Close finishes the file.
+type Writer interface {
+	Write(pessoa api.Pessoa) error
+	Flush() error
+	Close() error
+}
+
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case CSV:
+		return newCSVWriter(w), nil
+	case XLSX:
+		return newXLSXWriter(w), nil
+	case JSONL:
+		return &jsonlWriter{encoder: json.NewEncoder(w)}, nil
+	}
+	return nil, fmt.Errorf("unknown export format %q", format)
+}
+
+// Export writes the records selected by the filter and returns how many were
+// written. afterPage, when set, is called after each page was flushed.
+func Export(ctx context.Context, w io.Writer, format string, filter objects.PessoaFilter, afterPage func(written int)) (int, error) {
+	writer, err := NewWriter(format, w)
+	if err != nil {
+		return 0, err
+	}
+	written := 0
+	err = repository.ForEachPessoaPage(ctx, filter, PageSize, func(pessoas []*objects.PessoaResult) error {
+		for _, pessoa := range pessoas {
+			if err := writer.Write(api.NewPessoa(pessoa.Redacted())); err != nil {
+				return err
+			}
+			written++
+		}
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+		if afterPage != nil {
+			afterPage(written)
+		}
+		return nil
+	})
+	if err != nil {
+		return written, err
+	}
+	return written, writer.Close()
+}
+
+// ErrInvalidFilter is wrapped by the errors of ParseFilter, whose messages can be
+// shown to the users.
+var ErrInvalidFilter = errors.New("invalid filter")
+
+type filterError struct {
+	message string
+}
+
+func (e *filterError) Error() string {
+	return e.message
+}
+
+func (e *filterError) Unwrap() error {
+	return ErrInvalidFilter
+}
+
+// ParseFilter reads abrigo, cidade, source, from and to. The dates are days in the
+// same time zone as the statistics, and both are included.
+func ParseFilter(values url.Values) (objects.PessoaFilter, error) {
+	filter := objects.PessoaFilter{
+		SheetId: values.Get("source"),
+		Abrigo:  values.Get("abrigo"),
+		Cidade:  values.Get("cidade"),
+	}
+	if from := values.Get("from"); from != "" {
+		day, err := time.ParseInLocation(stats.DateLayout, from, stats.Location)
+		if err != nil {
+			return filter, &filterError{"A data from deve estar no formato AAAA-MM-DD."}
+		}
+		filter.From = day
+	}
+	if to := values.Get("to"); to != "" {
+		day, err := time.ParseInLocation(stats.DateLayout, to, stats.Location)
+		if err != nil {
+			return filter, &filterError{"A data to deve estar no formato AAAA-MM-DD."}
+		}
+		filter.To = day.AddDate(0, 0, 1)
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return filter, &filterError{"A data from deve ser anterior ou igual à data to."}
+	}
+	return filter, nil
+}
+
+// ParseFormat validates the format, empty meaning CSV.
+func ParseFormat(format string) (string, error) {
+	if format == "" {
+		return CSV, nil
+	}
+	if !slices.Contains(Formats, format) {
+		return "", &filterError{fmt.Sprintf("O formato %q não existe, use %s.", format, strings.Join(Formats, ", "))}
+	}
+	return format, nil
+}
+
+// row has the values of the Columns.
+func row(pessoa api.Pessoa) []string {
+	return []string{
+		pessoa.Nome,
+		pessoa.Abrigo,
+		pessoa.Idade,
+		pessoa.Observacao,
+		pessoa.SheetId,
+		pessoa.URL,
+		pessoa.Cidade,
+		formatTime(pessoa.UpdatedAt),
+		strconv.FormatBool(pessoa.Departed),
+	}
+}
+
+// formatTime writes the time as the people reading the spreadsheets see it.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(stats.Location).Format(time.RFC3339)
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+	header bool
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{writer: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) writeHeader() error {
+	if c.header {
+		return nil
+	}
+	c.header = true
+	return c.writer.Write(Columns)
+}
+
+func (c *csvWriter) Write(pessoa api.Pessoa) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	return c.writer.Write(row(pessoa))
+}
+
+func (c *csvWriter) Flush() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+func (c *csvWriter) Close() error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	return c.Flush()
+}
+
+type jsonlWriter struct {
+	encoder *json.Encoder
+}
+
+func (j *jsonlWriter) Write(pessoa api.Pessoa) error {
+	return j.encoder.Encode(pessoa)
+}
+
+func (j *jsonlWriter) Flush() error {
+	return nil
+}
+
+func (j *jsonlWriter) Close() error {
+	return nil
+}
@@ -0,0 +1,130 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"refugio/api"
+	"unicode/utf8"
+)
+
+// The smallest workbook the spreadsheet programs open: one sheet, no styles and
+// every text written inline, so that the rows can be streamed without a table of
+// shared strings.
+var xlsxParts = []struct{ name, content string }{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Pessoas" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`},
+}
+
+const (
+	xlsxSheetName  = "xl/worksheets/sheet1.xml"
+	xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetEnd = `</sheetData></worksheet>`
+)
+
+// Cells hold at most this many characters
+const xlsxMaxCellLength = 32767
+
+type xlsxWriter struct {
+	zip   *zip.Writer
+	sheet io.Writer
+	rows  int
+}
+
+func newXLSXWriter(w io.Writer) *xlsxWriter {
+	return &xlsxWriter{zip: zip.NewWriter(w)}
+}
+
+// start writes the parts before the sheet and the header row. Nothing is written
+// before the first record, so that a failed export can still be answered with an error.
+func (x *xlsxWriter) start() error {
+	if x.sheet != nil {
+		return nil
+	}
+	for _, part := range xlsxParts {
+		w, err := x.zip.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, part.content); err != nil {
+			return err
+		}
+	}
+	sheet, err := x.zip.Create(xlsxSheetName)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(sheet, xlsxSheetStart); err != nil {
+		return err
+	}
+	x.sheet = sheet
+	return x.writeRow(Columns, nil)
+}
+
+func (x *xlsxWriter) Write(pessoa api.Pessoa) error {
+	if err := x.start(); err != nil {
+		return err
+	}
+	departed := pessoa.Departed
+	return x.writeRow(row(pessoa)[:len(Columns)-1], &departed)
+}
+
+// writeRow writes the texts as inline strings, followed by the boolean when it is set.
+func (x *xlsxWriter) writeRow(texts []string, boolean *bool) error {
+	x.rows++
+	if _, err := fmt.Fprintf(x.sheet, `<row r="%d">`, x.rows); err != nil {
+		return err
+	}
+	for i, text := range texts {
+		if utf8.RuneCountInString(text) > xlsxMaxCellLength {
+			text = string([]rune(text)[:xlsxMaxCellLength])
+		}
+		if _, err := fmt.Fprintf(x.sheet, `<c r="%s%d" t="inlineStr"><is><t xml:space="preserve">`, xlsxColumn(i), x.rows); err != nil {
+			return err
+		}
+		if err := xml.EscapeText(x.sheet, []byte(text)); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(x.sheet, `</t></is></c>`); err != nil {
+			return err
+		}
+	}
+	if boolean != nil {
+		value := 0
+		if *boolean {
+			value = 1
+		}
+		if _, err := fmt.Fprintf(x.sheet, `<c r="%s%d" t="b"><v>%d</v></c>`, xlsxColumn(len(texts)), x.rows, value); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(x.sheet, `</row>`)
+	return err
+}
+
+func (x *xlsxWriter) Flush() error {
+	return x.zip.Flush()
+}
+
+func (x *xlsxWriter) Close() error {
+	if err := x.start(); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(x.sheet, xlsxSheetEnd); err != nil {
+		return err
+	}
+	return x.zip.Close()
+}
+
+// xlsxColumn names the column, there are fewer than 26 of them.
+func xlsxColumn(i int) string {
+	return string(rune('A' + i))
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"refugio/repository"
+	"refugio/scheduler"
+	"refugio/sheetscraper"
+	"refugio/utils/cuckoo"
+
+	"github.com/spf13/cobra"
+)
+
+var filterCmd = &cobra.Command{
+	Use:   "filter",
+	Short: "Maintain the dedup filter of the stored records",
+}
+
+var filterRebuildCmd = &cobra.Command{
+	Use:   "rebuild",
+	Short: "Recompute the filter from the stored records",
+	Long:  "Recompute the filter from the keys of every stored record, sized for the number of keys. It holds the scrape lock, so that no scrape writes the filter meanwhile.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		isDryRun, _ := cmd.Flags().GetBool("isDryRun")
+		return scheduler.WithLock(cmd.Context(), func(ctx context.Context) error {
+			keys, err := repository.FetchPessoaKeys(ctx)
+			if err != nil {
+				return err
+			}
+			filter, failed := cuckoo.Build(keys)
+			fmt.Printf("Built filter with %d keys, capacity %d, load factor %.2f\n", filter.Count(), cuckoo.Capacity(len(keys)), filter.LoadFactor())
+			if len(failed) > 0 {
+				return fmt.Errorf("%d keys did not fit in the filter, the stored filter was kept", len(failed))
+			}
+			if isDryRun {
+				fmt.Println("Dry run, the stored filter was kept")
+				return nil
+			}
+			return repository.UpdateFilterOnFirestore(ctx, sheetscraper.Pessoa, filter.Encode())
+		})
+	},
+}
+
+var filterVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Compare the stored filter with the stored records",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		showMissing, _ := cmd.Flags().GetInt("show-missing")
+		filter, err := cuckoo.GetCuckooFilter(cmd.Context(), sheetscraper.Pessoa)
+		if err != nil {
+			return err
+		}
+		keys, err := repository.FetchPessoaKeys(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		drift := cuckoo.Verify(filter, keys)
+		fmt.Printf("Stored records: %d\n", drift.Keys)
+		fmt.Printf("Filter entries: %d (load factor %.2f)\n", drift.Count, drift.LoadFactor)
+		fmt.Printf("Records missing from the filter: %d\n", len(drift.Missing))
+		for _, key := range drift.Missing[:min(showMissing, len(drift.Missing))] {
+			fmt.Printf("  %s\n", key)
+		}
+		fmt.Printf("Entries without a record (approximate): %d\n", drift.Stale)
+		if drift.LoadFactor > cuckoo.MaxLoadFactor {
+			fmt.Println("The filter is almost full")
+		}
+		if drift.HasDrift() || drift.LoadFactor > cuckoo.MaxLoadFactor {
+			return fmt.Errorf("the filter drifted from the stored records, run filter rebuild")
+		}
+		return nil
+	},
+}
+
+func init() {
+	filterRebuildCmd.Flags().Bool("isDryRun", false, "Build the filter without storing it")
+	filterVerifyCmd.Flags().Int("show-missing", 10, "How many of the missing keys to print")
+	filterCmd.AddCommand(filterRebuildCmd, filterVerifyCmd)
+}
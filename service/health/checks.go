@@ -0,0 +1,108 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"refugio/repository"
+	"refugio/scheduler"
+	"sync/atomic"
+	"time"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+)
+
+var draining atomic.Bool
+
+// SetDraining makes the Draining check fail while the server shuts down.
+func SetDraining(isDraining bool) {
+	draining.Store(isDraining)
+}
+
+func Draining() Check {
+	return Check{
+		Name:     "draining",
+		Critical: true,
+		Run: func(ctx context.Context) error {
+			if draining.Load() {
+				return errors.New("server is shutting down")
+			}
+			return nil
+		},
+	}
+}
+
+// Repository checks that Firestore answers.
+func Repository() Check {
+	return Check{
+		Name:     "repository",
+		Critical: true,
+		Run:      repository.Ping,
+	}
+}
+
+// Search checks that the Algolia index answers.
+func Search() Check {
+	return Check{
+		Name:     "search",
+		Critical: true,
+		Run: func(ctx context.Context) error {
+			client := search.NewClient(os.Getenv("ALGOLIA_CLIENT"), os.Getenv("ALGOLIA_API_KEY"))
+			_, err := client.InitIndex(os.Getenv("ALGOLIA_INDEX")).GetSettings(ctx)
+			return err
+		},
+	}
+}
+
+// AuthKeys checks that the keys could be loaded, using the given function.
+func AuthKeys(loaded func() error) Check {
+	return Check{
+		Name:     "auth_keys",
+		Critical: true,
+		Run: func(ctx context.Context) error {
+			return loaded()
+		},
+	}
+}
+
+// LastScrape checks that the last successful scrape is not older than maxAge. Stale data
+// is still served, so the check is not critical.
+func LastScrape(maxAge time.Duration) Check {
+	return Check{
+		Name: "last_scrape",
+		Run: func(ctx context.Context) error {
+			run, err := repository.FetchScrapeRun(ctx, repository.LastSuccessScrapeRun)
+			if err != nil {
+				return err
+			}
+			if run == nil {
+				return errors.New("no successful scrape recorded")
+			}
+			if age := time.Since(run.FinishedAt); age > maxAge {
+				return fmt.Errorf("last successful scrape finished %v ago", age.Round(time.Minute))
+			}
+			return nil
+		},
+	}
+}
+
+// Scheduler checks that a scrape of the scheduler running in this process is not stuck
+// for longer than maxRun.
+func Scheduler(maxRun time.Duration) Check {
+	return Check{
+		Name:     "scheduler",
+		Critical: true,
+		Run: func(ctx context.Context) error {
+			s := scheduler.Active()
+			if s == nil {
+				return nil
+			}
+			status := s.Status()
+			if status.Running && status.RunningSince != nil && time.Since(*status.RunningSince) > maxRun {
+				return fmt.Errorf("scrape running since %v", status.RunningSince.Format(time.RFC3339))
+			}
+			return nil
+		},
+	}
+}
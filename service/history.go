@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"refugio/api"
+	"refugio/repository"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history <id>",
+	Short: "Show the revisions of a record",
+	Long:  "Show every write to the record with the given id, its AggregateKey, with who made it and the fields it changed.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		revisions, err := repository.FetchRevisions(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+		if len(revisions) == 0 {
+			return fmt.Errorf("no revisions stored for %s", args[0])
+		}
+
+		history := api.NewHistory(args[0], revisions)
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "TIME\tACTION\tACTOR\tCHANGES")
+		for _, revision := range history.Revisions {
+			changes := strings.Join(revision.Changes, ", ")
+			if revision.TakedownId != "" {
+				changes = "takedown " + revision.TakedownId
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", revision.Timestamp.Format(time.DateTime), revision.Action, revision.Actor, changes)
+		}
+		return tw.Flush()
+	},
+}
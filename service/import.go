@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"refugio/scheduler"
+	"refugio/sheetscraper"
+	"refugio/stats"
+
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import curated records from a CSV or JSON lines file",
+	Long:  "Import curated records from a CSV or JSON lines file with the columns nome, abrigo, sheet_id and optionally idade, observacao, url and cidade. The rows go through the same cleaning, dedup and persistence as the scraped ones. When a row is invalid nothing is imported, unless --skip-invalid is given. It holds the scrape lock, so that no scrape writes the records meanwhile.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		isDryRun, _ := cmd.Flags().GetBool("isDryRun")
+		skipInvalid, _ := cmd.Flags().GetBool("skip-invalid")
+		output, _ := cmd.Flags().GetString("output")
+		if output != sheetscraper.OutputTable && output != sheetscraper.OutputJSON {
+			return fmt.Errorf("unknown output format %q", output)
+		}
+		format, _ := cmd.Flags().GetString("format")
+		if format == "" {
+			var err error
+			if format, err = sheetscraper.ImportFormat(args[0]); err != nil {
+				return err
+			}
+		}
+		file, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		opts := sheetscraper.ImportOptions{IsDryRun: isDryRun, Format: format, Name: filepath.Base(args[0]), SkipInvalid: skipInvalid}
+		var plan *sheetscraper.Plan
+		run := func(ctx context.Context) error {
+			var lineErrors []sheetscraper.LineError
+			var err error
+			plan, lineErrors, err = sheetscraper.Import(ctx, file, opts)
+			for _, lineError := range lineErrors {
+				fmt.Fprintln(os.Stderr, lineError.Error())
+			}
+			if err != nil {
+				return err
+			}
+			// Still under the lock, as after the scrapes
+			if !isDryRun && plan.HasChanges() {
+				if _, err := stats.Refresh(ctx, plan.Changed()); err != nil {
+					slog.ErrorContext(ctx, "Error refreshing statistics", "error", err)
+				}
+			}
+			return nil
+		}
+		if isDryRun {
+			err = run(cmd.Context())
+		} else {
+			err = scheduler.WithLock(cmd.Context(), run)
+		}
+		if plan != nil {
+			if writeErr := plan.Write(os.Stdout, output); writeErr != nil {
+				return errors.Join(err, writeErr)
+			}
+		}
+		return err
+	},
+}
+
+func init() {
+	importCmd.Flags().Bool("isDryRun", false, "Show what would be imported without writing anything")
+	importCmd.Flags().Bool("skip-invalid", false, "Import the valid rows even when others are invalid")
+	importCmd.Flags().String("format", "", "Format of the file, csv or jsonl. Guessed from the extension when empty")
+	importCmd.Flags().String("output", sheetscraper.OutputTable, "Output format of the plan: table or json")
+}
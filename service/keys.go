@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"refugio/objects"
+	"refugio/repository"
+	"refugio/utils/apikey"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var keysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Manage the API keys",
+}
+
+var keysCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a key and print it",
+	Long:  "Create a key and print it. Only a hash of the key is stored, so it cannot be shown again.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		scopes, _ := cmd.Flags().GetStringSlice("scopes")
+		expires, _ := cmd.Flags().GetDuration("expires")
+		rateLimit, err := keyRateLimit(cmd)
+		if err != nil {
+			return err
+		}
+		for _, scope := range scopes {
+			if !slices.Contains(objects.Scopes, scope) {
+				return fmt.Errorf("unknown scope %q, valid scopes are %v", scope, objects.Scopes)
+			}
+		}
+
+		id, secret, token := apikey.Generate()
+		key := &objects.ApiKey{
+			Id:        id,
+			Name:      name,
+			Hash:      apikey.Hash(secret),
+			Scopes:    scopes,
+			Enabled:   true,
+			CreatedAt: time.Now(),
+			RateLimit: rateLimit,
+		}
+		if expires > 0 {
+			expiresAt := key.CreatedAt.Add(expires)
+			key.ExpiresAt = &expiresAt
+		}
+		if err := repository.AddApiKeyToFirestore(cmd.Context(), key); err != nil {
+			return err
+		}
+		fmt.Printf("Created key %s for %s: %s\n", id, name, token)
+		return nil
+	},
+}
+
+var keysListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the keys",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		keys, err := repository.FetchApiKeysFromFirestore(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tNAME\tSCOPES\tENABLED\tRATE LIMIT\tEXPIRES\tLAST USED")
+		for _, key := range keys {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%v\t%s\t%s\t%s\n", key.Id, key.Name, strings.Join(key.Scopes, ","), key.Enabled, formatRateLimit(key.RateLimit), formatOptionalTime(key.ExpiresAt), formatOptionalTime(key.LastUsedAt))
+		}
+		return tw.Flush()
+	},
+}
+
+var keysRevokeCmd = &cobra.Command{
+	Use:   "revoke <id>",
+	Short: "Disable a key",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return updateKey(cmd.Context(), args[0], func(key *objects.ApiKey) {
+			key.Enabled = false
+		})
+	},
+}
+
+var keysEnableCmd = &cobra.Command{
+	Use:   "enable <id>",
+	Short: "Enable a key that was revoked",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return updateKey(cmd.Context(), args[0], func(key *objects.ApiKey) {
+			key.Enabled = true
+		})
+	},
+}
+
+var keysRotateCmd = &cobra.Command{
+	Use:   "rotate <id>",
+	Short: "Replace the secret of a key, keeping its name and scopes",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var token string
+		err := updateKey(cmd.Context(), args[0], func(key *objects.ApiKey) {
+			_, secret, _ := apikey.Generate()
+			now := time.Now()
+			key.Hash = apikey.Hash(secret)
+			key.RotatedAt = &now
+			token = apikey.Format(key.Id, secret)
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Println("New key:", token)
+		return nil
+	},
+}
+
+var keysLimitCmd = &cobra.Command{
+	Use:   "limit <id>",
+	Short: "Set the rate limit of a key, or go back to the route limits with --per-minute 0",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rateLimit, err := keyRateLimit(cmd)
+		if err != nil {
+			return err
+		}
+		return updateKey(cmd.Context(), args[0], func(key *objects.ApiKey) {
+			key.RateLimit = rateLimit
+		})
+	},
+}
+
+// keyRateLimit reads --per-minute and --burst. Zero requests per minute means the key
+// follows the limits of each route.
+func keyRateLimit(cmd *cobra.Command) (*objects.RateLimit, error) {
+	perMinute, _ := cmd.Flags().GetInt("per-minute")
+	burst, _ := cmd.Flags().GetInt("burst")
+	if perMinute < 0 || burst < 0 {
+		return nil, fmt.Errorf("--per-minute and --burst cannot be negative")
+	}
+	if perMinute == 0 {
+		return nil, nil
+	}
+	if burst == 0 {
+		burst = perMinute
+	}
+	return &objects.RateLimit{PerMinute: perMinute, Burst: burst}, nil
+}
+
+func updateKey(ctx context.Context, id string, update func(key *objects.ApiKey)) error {
+	key, err := repository.FetchApiKeyFromFirestore(ctx, id)
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		return fmt.Errorf("key %s not found", id)
+	}
+	update(key)
+	if err := repository.AddApiKeyToFirestore(ctx, key); err != nil {
+		return err
+	}
+	fmt.Printf("Key %s updated. Servers pick up the change within a minute\n", id)
+	return nil
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
+
+func formatRateLimit(limit *objects.RateLimit) string {
+	if limit == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d/min, burst %d", limit.PerMinute, limit.Burst)
+}
+
+func init() {
+	keysCreateCmd.Flags().String("name", "", "Who the key belongs to, shown in the access logs")
+	keysCreateCmd.Flags().StringSlice("scopes", []string{objects.ScopeSearch}, "Scopes granted to the key: search, sources, admin")
+	keysCreateCmd.Flags().Duration("expires", 0, "Expire the key after this long, e.g. 2160h. Never expires when zero")
+	keysCreateCmd.MarkFlagRequired("name")
+	for _, cmd := range []*cobra.Command{keysCreateCmd, keysLimitCmd} {
+		cmd.Flags().Int("per-minute", 0, "Requests per minute allowed to the key on each route. Uses the route limits when zero")
+		cmd.Flags().Int("burst", 0, "Requests the key can make at once. Defaults to --per-minute")
+	}
+	keysCmd.AddCommand(keysCreateCmd, keysListCmd, keysRevokeCmd, keysEnableCmd, keysRotateCmd, keysLimitCmd)
+}
@@ -0,0 +1,108 @@
+// Package logging configures log/slog for the service. In production it writes JSON
+// that Cloud Logging understands, locally it writes readable text.
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// Fields Cloud Logging uses to link the entries to a trace
+const (
+	TraceKey        = "logging.googleapis.com/trace"
+	SpanIdKey       = "logging.googleapis.com/spanId"
+	TraceSampledKey = "logging.googleapis.com/trace_sampled"
+)
+
+type contextKey struct{}
+
+// Setup installs the default logger. LOG_LEVEL picks the minimum level, info by default.
+func Setup(w io.Writer) {
+	slog.SetDefault(slog.New(NewHandler(w, os.Getenv("ENVIRONMENT") == "local")))
+}
+
+func NewHandler(w io.Writer, isLocal bool) slog.Handler {
+	opts := &slog.HandlerOptions{Level: parseLevel(os.Getenv("LOG_LEVEL"))}
+	if isLocal {
+		return &contextHandler{slog.NewTextHandler(w, opts)}
+	}
+	opts.ReplaceAttr = cloudLoggingAttr
+	return &contextHandler{slog.NewJSONHandler(w, opts)}
+}
+
+// With returns a context whose log entries carry the given attributes, for every
+// logger call that receives the context, e.g. slog.InfoContext(ctx, ...).
+func With(ctx context.Context, args ...any) context.Context {
+	attrs := attrsFromContext(ctx)
+	record := slog.Record{}
+	record.Add(args...)
+	merged := make([]slog.Attr, 0, len(attrs)+record.NumAttrs())
+	merged = append(merged, attrs...)
+	record.Attrs(func(attr slog.Attr) bool {
+		merged = append(merged, attr)
+		return true
+	})
+	return context.WithValue(ctx, contextKey{}, merged)
+}
+
+func attrsFromContext(ctx context.Context) []slog.Attr {
+	if ctx == nil {
+		return nil
+	}
+	attrs, _ := ctx.Value(contextKey{}).([]slog.Attr)
+	return attrs
+}
+
+// contextHandler adds the attributes stored by With to every record.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if attrs := attrsFromContext(ctx); len(attrs) > 0 {
+		record = record.Clone()
+		record.AddAttrs(attrs...)
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{h.Handler.WithGroup(name)}
+}
+
+// cloudLoggingAttr renames the standard fields to the ones Cloud Logging reads.
+func cloudLoggingAttr(groups []string, attr slog.Attr) slog.Attr {
+	if len(groups) > 0 {
+		return attr
+	}
+	switch attr.Key {
+	case slog.MessageKey:
+		attr.Key = "message"
+	case slog.LevelKey:
+		attr.Key = "severity"
+		if level, ok := attr.Value.Any().(slog.Level); ok && level == slog.LevelWarn {
+			attr.Value = slog.StringValue("WARNING")
+		}
+	}
+	return attr
+}
+
+func parseLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
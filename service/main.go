@@ -1,16 +1,33 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"refugio/api"
+	"refugio/health"
+	"refugio/logging"
+	"refugio/metrics"
+	"refugio/objects"
+	"refugio/repository"
+	"refugio/retention"
+	"refugio/scheduler"
 	"refugio/sheetscraper"
+	"refugio/sheetscraper/fakesheets"
+	"refugio/tracing"
 	"refugio/web"
 	"refugio/web/handlers"
+	"regexp"
+	"syscall"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
 var (
@@ -25,52 +42,404 @@ func init() {
 }
 
 func main() {
+	logging.Setup(os.Stderr)
+	shutdownTracing, err := tracing.Setup(context.Background())
+	if err != nil {
+		slog.Error("Error setting up tracing", "error", err)
+		os.Exit(1)
+	}
+
 	var rootCmd = &cobra.Command{Use: "app"}
 	rootCmd.AddCommand(webCmd)
 	rootCmd.AddCommand(scraperCmd)
-	if err := rootCmd.Execute(); err != nil {
-		log.Fatal(err)
+	rootCmd.AddCommand(daemonCmd)
+	rootCmd.AddCommand(fixturesCmd)
+	rootCmd.AddCommand(fakeSheetsCmd)
+	rootCmd.AddCommand(keysCmd)
+	rootCmd.AddCommand(openapiCmd)
+	rootCmd.AddCommand(countersCmd)
+	rootCmd.AddCommand(filterCmd)
+	rootCmd.AddCommand(statsCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(takedownCmd)
+	rootCmd.AddCommand(retentionCmd)
+	rootCmd.AddCommand(sourcesCmd)
+	err = rootCmd.ExecuteContext(context.Background())
+	shutdownTracing(context.Background())
+	if err != nil {
+		slog.Error("Command failed", "error", err)
+		os.Exit(1)
 	}
 }
 
 var webCmd = &cobra.Command{
 	Use:   "web",
 	Short: "Start the web server",
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := web.LoadLegacyKeys(os.Getenv("AUTH_KEYS_FILE")); err != nil {
+			slog.Warn("Error loading auth keys file", "error", err)
+		}
+
+		if err := web.SetRateLimitBackend(os.Getenv("RATE_LIMIT_BACKEND")); err != nil {
+			return err
+		}
+		if _, err := retention.CurrentPolicy(); err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		pessoaLimit := web.RouteRateLimit("pessoa", objects.RateLimit{PerMinute: 60, Burst: 20})
+		sourcesLimit := web.RouteRateLimit("sources", objects.RateLimit{PerMinute: 30, Burst: 10})
+		statsLimit := web.RouteRateLimit("stats", objects.RateLimit{PerMinute: 30, Burst: 10})
+		exportLimit := web.RouteRateLimit("export", objects.RateLimit{PerMinute: 2, Burst: 2})
+		takedownsLimit := web.RouteRateLimit("takedowns", objects.RateLimit{PerMinute: 5, Burst: 2})
+		// Every client IP, before its key is checked
+		ipLimit := web.RouteRateLimit("ip", objects.RateLimit{PerMinute: 300, Burst: 100})
+
 		router := mux.NewRouter()
-		router.Use(web.BaseRequestMiddleware)
-		/* /pessoa routes with caching and Auth */
-		pessoaSubrouter := router.PathPrefix("/pessoa").Subrouter()
-		pessoaSubrouter.Use(web.AuthMiddleware, web.CacheMiddleware)
-		pessoaSubrouter.HandleFunc("", handlers.GetPessoa).Methods(http.MethodGet, http.MethodOptions).Queries("nome", "{nome:[\\p{L}\\s0-9]{3,}}")
-		pessoaSubrouter.HandleFunc("/count", handlers.GetRecordCount).Methods(http.MethodGet, http.MethodOptions)
-		pessoaSubrouter.HandleFunc("/most_recent", handlers.GetMostRecent).Methods(http.MethodGet, http.MethodOptions)
-
-		router.Handle("/sources", web.AuthMiddleware(http.HandlerFunc(handlers.GetSources))).Methods(http.MethodGet, http.MethodOptions)
+		router.Use(web.BaseRequestMiddleware, web.RecoverMiddleware, web.IPRateLimitMiddleware(ipLimit))
+		router.NotFoundHandler = web.BaseRequestMiddleware(http.HandlerFunc(web.NotFound))
+		router.MethodNotAllowedHandler = web.BaseRequestMiddleware(http.HandlerFunc(web.MethodNotAllowed))
+		/* /pessoa routes with caching and Auth, the legacy ones and the versioned ones share limits */
+		pessoaRoutes := func(prefix string, search http.HandlerFunc) {
+			pessoaSubrouter := router.PathPrefix(prefix + "/pessoa").Subrouter()
+			pessoaSubrouter.Use(web.AuthMiddleware, web.RequireScope(objects.ScopeSearch), web.RateLimitMiddleware("pessoa", pessoaLimit), web.CacheMiddleware)
+			pessoaSubrouter.HandleFunc("", search).Methods(http.MethodGet, http.MethodOptions)
+			pessoaSubrouter.HandleFunc("/count", handlers.GetRecordCount).Methods(http.MethodGet, http.MethodOptions)
+			pessoaSubrouter.HandleFunc("/most_recent", handlers.GetMostRecent).Methods(http.MethodGet, http.MethodOptions)
+		}
+		pessoaRoutes("", handlers.GetPessoa)
+		pessoaRoutes(api.Version, handlers.GetPessoaV1)
+
+		router.Handle("/sources", web.AuthMiddleware(web.RequireScope(objects.ScopeSources)(web.RateLimitMiddleware("sources", sourcesLimit)(http.HandlerFunc(handlers.GetSources))))).Methods(http.MethodGet, http.MethodOptions)
+		router.Handle(api.Version+"/sources", web.AuthMiddleware(web.RequireScope(objects.ScopeSources)(web.RateLimitMiddleware("sources", sourcesLimit)(http.HandlerFunc(handlers.GetSourcesV1))))).Methods(http.MethodGet, http.MethodOptions)
+		for _, prefix := range []string{"", api.Version} {
+			router.Handle(prefix+"/stats", web.AuthMiddleware(web.RequireScope(objects.ScopeSearch)(web.RateLimitMiddleware("stats", statsLimit)(web.CacheMiddleware(http.HandlerFunc(handlers.GetStats)))))).Methods(http.MethodGet, http.MethodOptions)
+		}
+		router.Handle(api.Version+"/takedowns", web.AuthMiddleware(web.RequireScope(objects.ScopeSearch)(web.RateLimitMiddleware("takedowns", takedownsLimit)(http.HandlerFunc(handlers.CreateTakedown))))).Methods(http.MethodPost, http.MethodOptions)
+		router.HandleFunc("/openapi.json", handlers.GetOpenAPI).Methods(http.MethodGet, http.MethodOptions)
+		router.Handle("/scrape/status", web.AuthMiddleware(web.RequireScope(objects.ScopeAdmin)(http.HandlerFunc(handlers.GetScrapeStatus)))).Methods(http.MethodGet, http.MethodOptions)
+		router.Handle("/export", web.AuthMiddleware(web.RequireScope(objects.ScopeAdmin)(web.RateLimitMiddleware("export", exportLimit)(http.HandlerFunc(handlers.GetExport))))).Methods(http.MethodGet, http.MethodOptions)
+		router.Handle("/admin/pessoa/{id}/history", web.AuthMiddleware(web.RequireScope(objects.ScopeAdmin)(http.HandlerFunc(handlers.GetPessoaHistory)))).Methods(http.MethodGet, http.MethodOptions)
+		router.Handle("/admin/takedowns", web.AuthMiddleware(web.RequireScope(objects.ScopeAdmin)(http.HandlerFunc(handlers.GetTakedowns)))).Methods(http.MethodGet, http.MethodOptions)
+		router.Handle("/admin/takedowns/{id}/approve", web.AuthMiddleware(web.RequireScope(objects.ScopeAdmin)(http.HandlerFunc(handlers.ApproveTakedown)))).Methods(http.MethodPost, http.MethodOptions)
+		router.Handle("/admin/takedowns/{id}/reject", web.AuthMiddleware(web.RequireScope(objects.ScopeAdmin)(http.HandlerFunc(handlers.RejectTakedown)))).Methods(http.MethodPost, http.MethodOptions)
+		router.Handle("/admin/cache", web.AuthMiddleware(web.RequireScope(objects.ScopeAdmin)(http.HandlerFunc(handlers.PurgeCache)))).Methods(http.MethodDelete, http.MethodOptions)
 		router.Handle("/auth/me", web.AuthMiddleware(http.HandlerFunc(handlers.AuthMe))).Methods(http.MethodGet, http.MethodOptions)
 
-		router.HandleFunc("/health/ready", handlers.Ready).Methods(http.MethodGet, http.MethodOptions)
-		router.HandleFunc("/health/live", handlers.Live).Methods(http.MethodGet, http.MethodOptions)
+		router.Handle("/metrics", web.AuthMiddleware(web.RequireScope(objects.ScopeAdmin)(metrics.Handler()))).Methods(http.MethodGet, http.MethodOptions)
+		servers := []*http.Server{newServer(cmd, port, otelhttp.NewHandler(router, "http"))}
+		if metricsPort, _ := cmd.Flags().GetString("metrics-port"); metricsPort != "" {
+			// Unauthenticated, for a collector running next to the server
+			servers = append(servers, newServer(cmd, metricsPort, metrics.Handler()))
+		}
 
-		http.Handle("/", router)
+		maxScrapeAge, _ := cmd.Flags().GetDuration("max-scrape-age")
+		// Liveness only looks at this process, a backend outage should not restart it
+		liveChecks := health.NewSet(health.Scheduler(scheduler.LockTTL))
+		readyChecks := health.NewSet(
+			health.Draining(),
+			health.Repository(),
+			health.Search(),
+			health.AuthKeys(web.LegacyKeysLoaded),
+			health.LastScrape(maxScrapeAge),
+		)
+		router.Handle("/health/ready", health.Handler(readyChecks)).Methods(http.MethodGet, http.MethodOptions)
+		router.Handle("/health/live", health.Handler(liveChecks)).Methods(http.MethodGet, http.MethodOptions)
 
-		fmt.Println("Listening on port ", port)
-		err := http.ListenAndServe(fmt.Sprintf(":%s", port), nil)
-		if err != nil {
-			panic(err)
+		// Takedowns and cache purges made elsewhere reach the cache of this instance
+		go web.WatchCachePurges(ctx, web.CachePurgeCheckEvery)
+
+		if every, _ := cmd.Flags().GetDuration("scrape-every"); every > 0 {
+			jitter, _ := cmd.Flags().GetDuration("scrape-jitter")
+			isDryRun, _ := cmd.Flags().GetBool("scrape-dry-run")
+			s := &scheduler.Scheduler{
+				Interval: every,
+				Jitter:   jitter,
+				Options:  sheetscraper.ScrapeOptions{IsDryRun: isDryRun},
+				OnRun:    purgeCacheAfterScrape,
+			}
+			go s.Run(ctx)
 		}
+
+		drainDelay, _ := cmd.Flags().GetDuration("drain-delay")
+		shutdownTimeout, _ := cmd.Flags().GetDuration("shutdown-timeout")
+		return serve(ctx, servers, drainDelay, shutdownTimeout)
 	},
 }
 
+func newServer(cmd *cobra.Command, port string, handler http.Handler) *http.Server {
+	readTimeout, _ := cmd.Flags().GetDuration("read-timeout")
+	readHeaderTimeout, _ := cmd.Flags().GetDuration("read-header-timeout")
+	writeTimeout, _ := cmd.Flags().GetDuration("write-timeout")
+	idleTimeout, _ := cmd.Flags().GetDuration("idle-timeout")
+	maxHeaderBytes, _ := cmd.Flags().GetInt("max-header-bytes")
+	return &http.Server{
+		Addr:              fmt.Sprintf(":%s", port),
+		Handler:           handler,
+		ReadTimeout:       readTimeout,
+		ReadHeaderTimeout: readHeaderTimeout,
+		WriteTimeout:      writeTimeout,
+		IdleTimeout:       idleTimeout,
+		MaxHeaderBytes:    maxHeaderBytes,
+	}
+}
+
+// serve runs the servers until ctx is done. Readiness then fails for drainDelay, so
+// load balancers stop sending traffic, and in-flight requests get shutdownTimeout to
+// finish before the connections are closed.
+func serve(ctx context.Context, servers []*http.Server, drainDelay time.Duration, shutdownTimeout time.Duration) error {
+	errs := make(chan error, len(servers))
+	for _, server := range servers {
+		go func(server *http.Server) {
+			slog.Info("Listening", "addr", server.Addr)
+			if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errs <- err
+			}
+		}(server)
+	}
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.Info("Shutting down, draining in-flight requests", "drain_delay", drainDelay.String(), "timeout", shutdownTimeout.String())
+	health.SetDraining(true)
+	time.Sleep(drainDelay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	var shutdownErr error
+	for _, server := range servers {
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			slog.Error("Error shutting down server", "addr", server.Addr, "error", err)
+			shutdownErr = err
+		}
+	}
+	return shutdownErr
+}
+
 var scraperCmd = &cobra.Command{
 	Use:   "scrape",
 	Short: "Run the sheetscraper",
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		isDryRun, _ := cmd.Flags().GetBool("isDryRun")
-		sheetscraper.Scrape(isDryRun)
+		output, _ := cmd.Flags().GetString("output")
+		if output != sheetscraper.OutputTable && output != sheetscraper.OutputJSON {
+			return fmt.Errorf("unknown output format %q", output)
+		}
+		selector, err := scrapeSelector(cmd)
+		if err != nil {
+			return err
+		}
+		run, plan, err := scheduler.RunOnce(cmd.Context(), sheetscraper.ScrapeOptions{IsDryRun: isDryRun, Selector: selector})
+		exportScrapeMetrics(cmd)
+		purgeCacheAfterScrape(run, plan)
+		if err != nil {
+			return err
+		}
+		return plan.Write(os.Stdout, output)
+	},
+}
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Run the sheetscraper periodically",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		every, _ := cmd.Flags().GetDuration("every")
+		jitter, _ := cmd.Flags().GetDuration("jitter")
+		isDryRun, _ := cmd.Flags().GetBool("isDryRun")
+		if every <= 0 {
+			return fmt.Errorf("--every must be positive")
+		}
+		selector, err := scrapeSelector(cmd)
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		s := &scheduler.Scheduler{
+			Interval: every,
+			Jitter:   jitter,
+			Options:  sheetscraper.ScrapeOptions{IsDryRun: isDryRun, Selector: selector},
+			OnRun: func(run *objects.ScrapeRun, plan *sheetscraper.Plan) {
+				slog.Info("Scrape run finished", "scrape_run", run.Id, "success", run.Success, "counts", run.Counts)
+				exportScrapeMetrics(cmd)
+				purgeCacheAfterScrape(run, plan)
+			},
+		}
+		s.Run(ctx)
+		return nil
+	},
+}
+
+// purgeCacheAfterScrape drops the cached responses the changes of the run affect in
+// this process, and marks the cache as purged for every web server.
+func purgeCacheAfterScrape(run *objects.ScrapeRun, plan *sheetscraper.Plan) {
+	if plan == nil || !plan.HasChanges() || plan.IsDryRun {
+		return
+	}
+	web.PurgeCacheFor(plan.ChangedNames())
+	if err := repository.MarkCachePurged(context.Background(), time.Now()); err != nil {
+		slog.Error("Error marking the cache as purged, the web servers keep their responses until they expire", "scrape_run", run.Id, "error", err)
+	}
+}
+
+func addMetricsFlags(cmd *cobra.Command) {
+	cmd.Flags().String("metrics-textfile", "", "Write the scrape metrics to this file, for the node exporter textfile collector")
+	cmd.Flags().String("metrics-push", "", "Push the scrape metrics to this Pushgateway URL")
+}
+
+// exportScrapeMetrics writes or pushes the scrape metrics as asked by the flags. A
+// failed export is logged, it does not fail the scrape.
+func exportScrapeMetrics(cmd *cobra.Command) {
+	if path, _ := cmd.Flags().GetString("metrics-textfile"); path != "" {
+		if err := metrics.WriteTextfile(path); err != nil {
+			slog.Error("Error writing metrics textfile", "path", path, "error", err)
+		}
+	}
+	if url, _ := cmd.Flags().GetString("metrics-push"); url != "" {
+		if err := metrics.Push(url); err != nil {
+			slog.Error("Error pushing metrics", "url", url, "error", err)
+		}
+	}
+}
+
+func addSelectorFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("source", nil, "Only scrape these spreadsheet ids")
+	cmd.Flags().StringSlice("range", nil, "Only scrape these tabs, by tab name")
+	cmd.Flags().String("name-match", "", "Only scrape sources whose name matches this regex")
+	cmd.Flags().StringSlice("exclude", nil, "Skip these spreadsheet ids")
+	cmd.Flags().Bool("include-disabled", false, "Also scrape the sources disabled after failing, enabling the ones that are read")
+}
+
+func scrapeSelector(cmd *cobra.Command) (sheetscraper.Selector, error) {
+	var selector sheetscraper.Selector
+	selector.SourceIds, _ = cmd.Flags().GetStringSlice("source")
+	selector.Ranges, _ = cmd.Flags().GetStringSlice("range")
+	selector.Exclude, _ = cmd.Flags().GetStringSlice("exclude")
+	selector.IncludeDisabled, _ = cmd.Flags().GetBool("include-disabled")
+	if nameMatch, _ := cmd.Flags().GetString("name-match"); nameMatch != "" {
+		re, err := regexp.Compile(nameMatch)
+		if err != nil {
+			return selector, fmt.Errorf("invalid --name-match: %w", err)
+		}
+		selector.NameMatch = re
+	}
+	return selector, nil
+}
+
+var fixturesCmd = &cobra.Command{
+	Use:   "fixtures",
+	Short: "Record sheet fixtures and check the parsers against golden files",
+}
+
+var fixturesRecordCmd = &cobra.Command{
+	Use:   "record",
+	Short: "Record a fixture from a live sheet range",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sheetId, _ := cmd.Flags().GetString("sheetId")
+		sheetRange, _ := cmd.Flags().GetString("range")
+		dir, _ := cmd.Flags().GetString("fixtures")
+		path, err := sheetscraper.RecordFixture(cmd.Context(), sheetId, sheetRange, dir)
+		if err != nil {
+			return err
+		}
+		fmt.Println("Fixture recorded at", path)
+		return nil
+	},
+}
+
+var fixturesVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Run every fixture through the parsers and compare with the golden files",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fixturesDir, _ := cmd.Flags().GetString("fixtures")
+		goldenDir, _ := cmd.Flags().GetString("goldens")
+		mismatches, err := sheetscraper.VerifyGoldens(fixturesDir, goldenDir)
+		if err != nil {
+			return err
+		}
+		for _, mismatch := range mismatches {
+			fmt.Fprintln(os.Stderr, mismatch)
+		}
+		if len(mismatches) > 0 {
+			return fmt.Errorf("%d fixture(s) differ from their golden files", len(mismatches))
+		}
+		fmt.Println("All fixtures match their golden files")
+		return nil
+	},
+}
+
+var fixturesUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Rewrite the golden files with the current parser output",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fixturesDir, _ := cmd.Flags().GetString("fixtures")
+		goldenDir, _ := cmd.Flags().GetString("goldens")
+		written, err := sheetscraper.UpdateGoldens(fixturesDir, goldenDir)
+		for _, path := range written {
+			fmt.Println("Updated", path)
+		}
+		return err
+	},
+}
+
+var fakeSheetsCmd = &cobra.Command{
+	Use:   "fakesheets",
+	Short: "Serve recorded fixtures through a local stand-in for the Google Sheets API",
+	Run: func(cmd *cobra.Command, args []string) {
+		dir, _ := cmd.Flags().GetString("fixtures")
+		fakePort, _ := cmd.Flags().GetString("port")
+		server := fakesheets.NewServer(dir)
+
+		fmt.Printf("Serving fixtures from %s. Use SHEETS_API_ENDPOINT=http://localhost:%s/\n", dir, fakePort)
+		err := http.ListenAndServe(fmt.Sprintf(":%s", fakePort), server.Handler())
+		if err != nil {
+			panic(err)
+		}
 	},
 }
 
 func init() {
 	scraperCmd.Flags().Bool("isDryRun", false, "Enable dry-run mode without making actual changes")
+	scraperCmd.Flags().String("output", sheetscraper.OutputTable, "Plan output format: table or json")
+	addSelectorFlags(scraperCmd)
+	addMetricsFlags(scraperCmd)
+
+	webCmd.Flags().Duration("scrape-every", 0, "Also scrape periodically inside the server, e.g. 15m. Disabled when zero")
+	webCmd.Flags().Duration("scrape-jitter", time.Minute, "Maximum random delay added before each scheduled scrape")
+	webCmd.Flags().Bool("scrape-dry-run", false, "Run the scheduled scrapes in dry-run mode")
+	webCmd.Flags().String("metrics-port", "", "Also serve /metrics without authentication on this port")
+	webCmd.Flags().Duration("read-timeout", 15*time.Second, "Maximum duration for reading a whole request")
+	webCmd.Flags().Duration("read-header-timeout", 5*time.Second, "Maximum duration for reading the request headers")
+	webCmd.Flags().Duration("write-timeout", 30*time.Second, "Maximum duration for writing a response")
+	webCmd.Flags().Duration("idle-timeout", 2*time.Minute, "How long idle keep-alive connections are kept open")
+	webCmd.Flags().Int("max-header-bytes", 64<<10, "Maximum size of the request headers")
+	webCmd.Flags().Duration("drain-delay", 3*time.Second, "How long /health/ready fails before the server stops accepting connections on shutdown")
+	webCmd.Flags().Duration("max-scrape-age", 6*time.Hour, "Readiness reports degraded when the last successful scrape is older than this")
+	webCmd.Flags().Duration("shutdown-timeout", 6*time.Second, "How long in-flight requests get to finish on shutdown")
+
+	daemonCmd.Flags().Duration("every", 15*time.Minute, "Default interval between scrapes of a source")
+	daemonCmd.Flags().Duration("jitter", time.Minute, "Maximum random delay added before each scrape")
+	daemonCmd.Flags().Bool("isDryRun", false, "Enable dry-run mode without making actual changes")
+	addSelectorFlags(daemonCmd)
+	addMetricsFlags(daemonCmd)
+
+	fixturesCmd.PersistentFlags().String("fixtures", sheetscraper.DefaultFixturesDir, "Directory holding the recorded fixtures")
+	fixturesVerifyCmd.Flags().String("goldens", sheetscraper.DefaultGoldenDir, "Directory holding the golden files")
+	fixturesUpdateCmd.Flags().String("goldens", sheetscraper.DefaultGoldenDir, "Directory holding the golden files")
+	fixturesRecordCmd.Flags().String("sheetId", "", "Spreadsheet id to record")
+	fixturesRecordCmd.Flags().String("range", "", "Sheet range to record, as written in config.go")
+	fixturesRecordCmd.MarkFlagRequired("sheetId")
+	fixturesRecordCmd.MarkFlagRequired("range")
+	fixturesCmd.AddCommand(fixturesRecordCmd, fixturesVerifyCmd, fixturesUpdateCmd)
+
+	fakeSheetsCmd.Flags().String("fixtures", sheetscraper.DefaultFixturesDir, "Directory holding the recorded fixtures")
+	fakeSheetsCmd.Flags().String("port", "8085", "Port to listen on")
 }
@@ -0,0 +1,109 @@
+// Package metrics holds the Prometheus collectors of the web server and the scraper.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+const namespace = "refugio"
+
+var (
+	// Registry holds the web server and backend metrics
+	Registry = prometheus.NewRegistry()
+	// ScrapeRegistry holds the scrape metrics, the ones exported by `app scrape`
+	ScrapeRegistry = prometheus.NewRegistry()
+)
+
+var (
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Duration of the HTTP requests by route, method and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	CacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_requests_total",
+		Help:      "Response cache lookups by result, hit or miss.",
+	}, []string{"result"})
+
+	BackendDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "backend_request_duration_seconds",
+		Help:      "Duration of the calls to Firestore, Algolia and the Sheets API by operation.",
+		Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"backend", "operation"})
+)
+
+var (
+	ScrapeRows = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "scrape_rows_total",
+		Help:      "Rows handled by the scraper by source and result: read, written or failed.",
+	}, []string{"sheet_id", "result"})
+
+	ScrapeRanges = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "scrape_ranges_total",
+		Help:      "Sheet ranges scraped by source and result: ok or failed.",
+	}, []string{"sheet_id", "result"})
+
+	ScrapeLastRun = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "scrape_last_run_timestamp_seconds",
+		Help:      "When the last scrape finished, by outcome.",
+	}, []string{"success"})
+
+	ScrapeDuration = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "scrape_last_run_duration_seconds",
+		Help:      "How long the last scrape took.",
+	})
+)
+
+/* Scrape row results */
+const (
+	RowsRead    = "read"
+	RowsWritten = "written"
+	RowsFailed  = "failed"
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestDuration,
+		CacheRequests,
+		BackendDuration,
+	)
+	ScrapeRegistry.MustRegister(ScrapeRows, ScrapeRanges, ScrapeLastRun, ScrapeDuration)
+}
+
+// Handler serves every metric, the scrape ones included when the scheduler runs in
+// the same process.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(prometheus.Gatherers{Registry, ScrapeRegistry}, promhttp.HandlerOpts{})
+}
+
+// ObserveBackend records a backend call started at start, meant to be deferred:
+// defer metrics.ObserveBackend("firestore", "GetAll", time.Now())
+func ObserveBackend(backend string, operation string, start time.Time) {
+	BackendDuration.WithLabelValues(backend, operation).Observe(time.Since(start).Seconds())
+}
+
+// WriteTextfile writes the scrape metrics for the node exporter textfile collector.
+func WriteTextfile(path string) error {
+	return prometheus.WriteToTextfile(path, ScrapeRegistry)
+}
+
+// Push sends the scrape metrics to a Pushgateway.
+func Push(url string) error {
+	return push.New(url, namespace+"_scrape").Gatherer(ScrapeRegistry).Push()
+}
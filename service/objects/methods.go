@@ -4,6 +4,7 @@ import (
 	"refugio/utils"
 	"regexp"
 	"strings"
+	"time"
 
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -40,6 +41,89 @@ func (p *PessoaResult) Validate() (bool, *PessoaResult) {
 	return true, p
 }
 
+// Redacted returns a copy of the record with the personal information that made it
+// into its free text fields replaced, for records that leave the service in bulk.
+func (p *PessoaResult) Redacted() *PessoaResult {
+	redacted := *p
+	if p.Pessoa != nil {
+		pessoa := *p.Pessoa
+		pessoa.Nome = redactPII(pessoa.Nome)
+		pessoa.Idade = redactPII(pessoa.Idade)
+		pessoa.Observacao = redactPII(pessoa.Observacao)
+		redacted.Pessoa = &pessoa
+	}
+	return &redacted
+}
+
+// ClearFreeText empties the fields where the sensitive details of a record are
+// written, for the records under a redact takedown.
+func (p *PessoaResult) ClearFreeText() *PessoaResult {
+	p.Idade = ""
+	p.Observacao = ""
+	return p
+}
+
+// Correct sets the free text fields of a record under a correct takedown to the
+// corrected ones.
+func (p *PessoaResult) Correct(correction *Correction) *PessoaResult {
+	if correction != nil {
+		p.Idade = correction.Idade
+		p.Observacao = correction.Observacao
+	}
+	return p
+}
+
+// Created is when the record was first stored, its Timestamp for the records stored
+// before CreatedAt was kept.
+func (p *PessoaResult) Created() time.Time {
+	if p.CreatedAt.IsZero() {
+		return p.Timestamp
+	}
+	return p.CreatedAt
+}
+
+// Updated is when the record was last written, its Timestamp for the records not
+// written since UpdatedAt was kept.
+func (p *PessoaResult) Updated() time.Time {
+	if p.UpdatedAt.IsZero() {
+		return p.Timestamp
+	}
+	return p.UpdatedAt
+}
+
+// LastConfirmed is when a scrape or an import last found the record in its source,
+// either writing it or finding it unchanged.
+func (p *PessoaResult) LastConfirmed() time.Time {
+	if updated := p.Updated(); !p.LastSeen.After(updated) {
+		return updated
+	}
+	return p.LastSeen
+}
+
+// RedactedText replaces the personal information in the free text fields.
+const RedactedText = "[removido]"
+
+var (
+	regexEmails = regexp.MustCompile(`[\w.+-]+@[\w-]+(\.[\w-]+)+`)
+	// Phones and document numbers, with or without separators. Dates are kept, their
+	// slashes are not a separator here
+	regexDocumentNumbers = regexp.MustCompile(`\+?\(?\d[\d\s().-]*\d`)
+	regexDigits          = regexp.MustCompile(`\d`)
+)
+
+// Numbers with fewer digits are ages, dates and room numbers
+const minRedactedDigits = 8
+
+func redactPII(str string) string {
+	str = regexEmails.ReplaceAllString(str, RedactedText)
+	return regexDocumentNumbers.ReplaceAllStringFunc(str, func(number string) string {
+		if len(regexDigits.FindAllString(number, -1)) < minRedactedDigits {
+			return number
+		}
+		return RedactedText
+	})
+}
+
 func (p *PessoaResult) AggregateKey() string {
 	caser := cases.Lower(language.BrazilianPortuguese)
 	return utils.RemoveAccents(caser.String(onlyLettersAndNumbers(p.Nome + p.Abrigo)))
@@ -58,6 +142,9 @@ func cleanNome(name string) string {
 	regexPhoneNumbers := regexp.MustCompile(`\d{3,}`)
 	name = regexPhoneNumbers.ReplaceAllString(name, "")
 
+	// The numbers removed may leave spaces behind
+	name = strings.TrimSpace(utils.RemoveExtraSpaces(name))
+
 	return name
 }
 
@@ -82,3 +169,83 @@ func onlyLettersAndNumbers(str string) string {
 	result := re.ReplaceAllString(str, "")
 	return result
 }
+
+/* ApiKey scopes and status */
+func (k *ApiKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+func (k *ApiKey) IsActive(now time.Time) bool {
+	if !k.Enabled {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+/* Token bucket */
+
+// Take refills the bucket for the time elapsed since its last update and takes one
+// token if there is one. It returns whether the token was taken.
+func (b *TokenBucket) Take(limit RateLimit, now time.Time) bool {
+	perSecond := float64(limit.PerMinute) / 60
+	if b.UpdatedAt.IsZero() {
+		b.Tokens = float64(limit.Burst)
+	} else if elapsed := now.Sub(b.UpdatedAt).Seconds(); elapsed > 0 {
+		b.Tokens = min(float64(limit.Burst), b.Tokens+elapsed*perSecond)
+	}
+	b.UpdatedAt = now
+
+	if b.Tokens < 1 {
+		return false
+	}
+	b.Tokens--
+	return true
+}
+
+// Wait is how long until the bucket holds n tokens.
+func (b *TokenBucket) Wait(limit RateLimit, n float64) time.Duration {
+	missing := n - b.Tokens
+	if missing <= 0 || limit.PerMinute <= 0 {
+		return 0
+	}
+	return time.Duration(missing / (float64(limit.PerMinute) / 60) * float64(time.Second))
+}
+
+// Record adds the outcome of a scrape of the source, err being nil when every range
+// was read. The source is disabled after maxFailures in a row, zero never disabling it,
+// and a read that works enables it again. It returns whether the source was just disabled.
+func (h *SourceHealth) Record(err error, now time.Time, maxFailures int) bool {
+	if err == nil {
+		h.Failures = 0
+		h.LastSuccessAt = &now
+		h.Disabled = false
+		h.DisabledAt = nil
+		return false
+	}
+	h.Failures++
+	h.LastError = err.Error()
+	h.LastErrorAt = &now
+	if h.Disabled || maxFailures <= 0 || h.Failures < maxFailures {
+		return false
+	}
+	h.Disabled = true
+	h.DisabledAt = &now
+	return true
+}
+
+func (h *SourceHealth) Status() string {
+	switch {
+	case h.Disabled:
+		return SourceDisabled
+	case h.Failures > 0:
+		return SourceFailing
+	case h.LastSuccessAt != nil:
+		return SourceOK
+	}
+	return SourceUnknown
+}
@@ -14,9 +14,30 @@ type Pessoa struct {
 
 type PessoaResult struct {
 	*Pessoa
-	SheetId   *string
-	URL       *string
+	SheetId *string
+	URL     *string
+	Cidade  string
+	// Timestamp is the first write time. Records stored before it was kept may have
+	// their last update time instead.
 	Timestamp time.Time
+	UpdatedAt time.Time
+	CreatedAt time.Time
+	// LastSeen is when a scrape or an import last found the record in its source
+	LastSeen time.Time
+	Departed bool
+	// The sheet range a scrape last read the record from, empty for imported records
+	OriginSheetId string
+	OriginRange   string
+}
+
+// PessoaFilter selects stored records, empty fields match every record. The records
+// first stored from From, inclusive, until To, exclusive, are selected.
+type PessoaFilter struct {
+	SheetId string
+	Abrigo  string
+	Cidade  string
+	From    time.Time
+	To      time.Time
 }
 
 type PessoaSearchResult struct {
@@ -24,18 +45,201 @@ type PessoaSearchResult struct {
 	Nome     string
 }
 
-type PessoaCountResult struct {
-	Total int `json:"total_records"`
+// PessoaCounters counts the stored PessoaResults, kept up to date by the repository
+// as records are written and deleted.
+type PessoaCounters struct {
+	Total    int
+	Departed int
+	// BySource is keyed by SheetId and ByAbrigo by Abrigo
+	BySource  map[string]int
+	ByAbrigo  map[string]int
+	UpdatedAt time.Time
+	RebuiltAt *time.Time
+}
+
+// StatsDay counts the stored records created on a day, split in groups of
+// source, abrigo and city so that any of them can be aggregated.
+type StatsDay struct {
+	// Date is formatted as 2006-01-02
+	Date       string
+	Total      int
+	Groups     []StatsGroup
+	ComputedAt time.Time
+}
+
+type StatsGroup struct {
+	SheetId  string
+	Abrigo   string
+	Cidade   string
+	Total    int
+	Departed int
+}
+
+/* Revision actions */
+const (
+	RevisionInsert   = "insert"
+	RevisionUpdate   = "update"
+	RevisionDeparted = "departed"
+	RevisionDelete   = "delete"
+	// RevisionTakedown holds no data of the record
+	RevisionTakedown = "takedown"
+)
+
+/* Actor kinds */
+const (
+	ActorScrape  = "scrape"
+	ActorImport  = "import"
+	ActorKey     = "key"
+	ActorCLI     = "cli"
+	ActorUnknown = "unknown"
+)
+
+// Actor is who wrote a record. Id is the scrape run, the imported file, the api key or
+// the user running the command.
+type Actor struct {
+	Kind string
+	Id   string
+}
+
+func (a Actor) String() string {
+	if a.Id == "" {
+		return a.Kind
+	}
+	return a.Kind + ":" + a.Id
 }
 
-type PessoaMostRecentResult struct {
-	Timestamp *time.Time `json:"timestamp"`
+// Revision is what a write did to a record. Before is nil for inserts and takedowns and
+// After for deletes and takedowns.
+type Revision struct {
+	Action    string
+	Actor     Actor
+	Before    *PessoaResult
+	After     *PessoaResult
+	Timestamp time.Time
+	// TakedownId is the takedown applied, for the takedown revisions
+	TakedownId string
 }
 
+/* Takedown kinds */
+const (
+	TakedownRemove  = "remove"
+	TakedownRedact  = "redact"
+	TakedownCorrect = "correct"
+)
+
+var TakedownKinds = []string{TakedownRemove, TakedownRedact, TakedownCorrect}
+
+/* Takedown statuses */
+const (
+	TakedownPending  = "pending"
+	TakedownApproved = "approved"
+	TakedownRejected = "rejected"
+)
+
+/* Takedown events */
+const (
+	TakedownRequested = "requested"
+)
+
+// Takedown is a request to remove, redact or correct a published record. Events keeps
+// everything that was done with the request.
+type Takedown struct {
+	Id string
+	// Key is the AggregateKey of the record
+	Key  string
+	Kind string
+	// Correction is set for the correct takedowns
+	Correction *Correction
+	Reason     string
+	Contact    string
+	Status     string
+	Events     []TakedownEvent
+	CreatedAt  time.Time
+	// DecidedAt is when it was approved or rejected
+	DecidedAt *time.Time
+}
+
+type TakedownEvent struct {
+	// Action is requested, approved or rejected
+	Action    string
+	Actor     Actor
+	Note      string
+	Timestamp time.Time
+}
+
+// Correction holds the corrected free text fields of a record.
+type Correction struct {
+	Idade      string
+	Observacao string
+}
+
+// Suppression keeps an approved takedown applied to the scraped records with its key.
+type Suppression struct {
+	Key        string
+	Kind       string
+	Correction *Correction
+	TakedownId string
+	CreatedAt  time.Time
+}
+
+// AccessLog is filled in while a request is handled and logged once it is served.
 type AccessLog struct {
-	Trace   *string `json:"logging.googleapis.com/trace"`
-	UserIP  *string `json:"user_ip"`
-	KeyUser *string `json:"key_user"`
+	RequestId   string
+	Trace       string
+	UserIP      string
+	KeyUser     string
+	Route       string
+	ResultCount *int
+}
+
+/* ApiKey scopes */
+const (
+	ScopeSearch  = "search"
+	ScopeSources = "sources"
+	ScopeAdmin   = "admin"
+)
+
+var Scopes = []string{ScopeSearch, ScopeSources, ScopeAdmin}
+
+type ApiKey struct {
+	Id         string
+	Name       string
+	Hash       string
+	Scopes     []string
+	Enabled    bool
+	CreatedAt  time.Time
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RotatedAt  *time.Time
+	// RateLimit overrides the limits of every route for this key
+	RateLimit *RateLimit
+}
+
+type RateLimit struct {
+	PerMinute int
+	Burst     int
+}
+
+type TokenBucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+type Lock struct {
+	Owner     string
+	ExpiresAt time.Time
+}
+
+type ScrapeRun struct {
+	Id         string         `json:"id"`
+	Owner      string         `json:"owner"`
+	IsDryRun   bool           `json:"is_dry_run"`
+	StartedAt  time.Time      `json:"started_at"`
+	FinishedAt time.Time      `json:"finished_at"`
+	SheetIds   []string       `json:"sheet_ids"`
+	Counts     map[string]int `json:"counts"`
+	Success    bool           `json:"success"`
+	Error      string         `json:"error,omitempty"`
 }
 
 type Source struct {
@@ -44,6 +248,34 @@ type Source struct {
 	URL     string
 	Observacao	string
 	Sheets  []string
+	// Configured sources are the ones in config.go, the others are listed by the Planilhão
+	Configured  bool
+	Organizacao string
+	Contato     string
+	Cidade      string
+	Regiao      string
+	ModifiedAt  *time.Time
+	ScrapedAt   *time.Time
+	Health      SourceHealth
+}
+
+/* Source statuses */
+const (
+	SourceOK       = "ok"
+	SourceFailing  = "failing"
+	SourceDisabled = "disabled"
+	SourceUnknown  = "unknown"
+)
+
+// SourceHealth tracks whether the spreadsheet of a configured source can still be read.
+type SourceHealth struct {
+	// Failures counts the scrapes in a row that could not read the source
+	Failures      int
+	LastError     string
+	LastErrorAt   *time.Time
+	LastSuccessAt *time.Time
+	Disabled      bool
+	DisabledAt    *time.Time
 }
 
 func (s *Source) String() string {
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"refugio/api"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var openapiCmd = &cobra.Command{
+	Use:   "openapi",
+	Short: "Print the OpenAPI document of the versioned API",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(api.Spec())
+	},
+}
+
+var openapiCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check that a running server answers as the OpenAPI document says",
+	Long: "Call every GET route of the OpenAPI document on a running server and validate the responses " +
+		"against their schemas. Error responses are validated too, so it can run against a server without backends.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		baseURL, _ := cmd.Flags().GetString("url")
+		key, _ := cmd.Flags().GetString("key")
+		rawParams, _ := cmd.Flags().GetStringToString("param")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+
+		params := map[string]string{"nome": "maria"}
+		for name, value := range rawParams {
+			params[name] = value
+		}
+		client := &http.Client{Timeout: timeout}
+		results := api.Spec().Check(cmd.Context(), client, strings.TrimSuffix(baseURL, "/"), key, params)
+
+		failed := 0
+		for _, result := range results {
+			if len(result.Problems) == 0 {
+				fmt.Printf("ok   %s %s (%d)\n", result.Method, result.Path, result.Status)
+				continue
+			}
+			failed++
+			fmt.Printf("FAIL %s %s (%d)\n", result.Method, result.Path, result.Status)
+			for _, problem := range result.Problems {
+				fmt.Printf("       %s\n", problem)
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d routes do not conform to the OpenAPI document", failed, len(results))
+		}
+		return nil
+	},
+}
+
+func init() {
+	openapiCheckCmd.Flags().String("url", "http://localhost:8080", "Base URL of the server")
+	openapiCheckCmd.Flags().String("key", os.Getenv("API_KEY"), "API key sent in the Authorization header")
+	openapiCheckCmd.Flags().StringToString("param", nil, "Value of a query parameter, like nome=maria (can repeat)")
+	openapiCheckCmd.Flags().Duration("timeout", 30*time.Second, "Timeout of each request")
+	openapiCmd.AddCommand(openapiCheckCmd)
+}
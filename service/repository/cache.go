@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"refugio/metrics"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+/* CachePurges documents */
+const LastCachePurge = "last"
+
+// MarkCachePurged stores that the cached responses of every instance must be dropped.
+func MarkCachePurged(ctx context.Context, purgedAt time.Time) error {
+	defer metrics.ObserveBackend("firestore", "MarkCachePurged", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return err
+	}
+	defer client.Close()
+
+	if _, err := client.Collection(CachePurges).Doc(LastCachePurge).Set(ctx, map[string]interface{}{"PurgedAt": purgedAt}); err != nil {
+		slog.ErrorContext(ctx, "Failed to mark cache purge", "error", err)
+		return err
+	}
+	return nil
+}
+
+// FetchCachePurgedAt returns when the cache was last marked as purged, the zero time
+// when it never was.
+func FetchCachePurgedAt(ctx context.Context) (time.Time, error) {
+	defer metrics.ObserveBackend("firestore", "FetchCachePurgedAt", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return time.Time{}, err
+	}
+	defer client.Close()
+
+	doc, err := client.Collection(CachePurges).Doc(LastCachePurge).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to retrieve cache purge", "error", err)
+		return time.Time{}, err
+	}
+	purgedAt, _ := doc.Data()["PurgedAt"].(time.Time)
+	return purgedAt, nil
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"refugio/metrics"
+	"refugio/objects"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+/* Counters documents */
+const PessoaCounters = "PessoasAbrigos"
+
+// Each transaction writes its records, a revision of each and the counters, under the
+// limit of 500 writes
+const pessoasPerTransaction = 200
+
+// UnknownCounterKey replaces an empty SheetId or Abrigo, Firestore has no empty field names
+const UnknownCounterKey = "desconhecido"
+
+// counterDelta is the change a transaction makes to the counters.
+type counterDelta struct {
+	total    int
+	departed int
+	bySource map[string]int
+	byAbrigo map[string]int
+}
+
+func newCounterDelta() *counterDelta {
+	return &counterDelta{bySource: map[string]int{}, byAbrigo: map[string]int{}}
+}
+
+// add counts the record n times, -1 taking it out of the counters.
+func (d *counterDelta) add(pessoa *objects.PessoaResult, n int) {
+	d.total += n
+	if pessoa.Departed {
+		d.departed += n
+	}
+	sheetId := ""
+	if pessoa.SheetId != nil {
+		sheetId = *pessoa.SheetId
+	}
+	abrigo := ""
+	if pessoa.Pessoa != nil {
+		abrigo = pessoa.Abrigo
+	}
+	d.bySource[counterKey(sheetId)] += n
+	d.byAbrigo[counterKey(abrigo)] += n
+}
+
+// apply adds the delta to the counters inside the transaction.
+func (d *counterDelta) apply(tx *firestore.Transaction, doc *firestore.DocumentRef) error {
+	update := map[string]interface{}{"UpdatedAt": time.Now()}
+	if d.total != 0 {
+		update["Total"] = firestore.Increment(d.total)
+	}
+	if d.departed != 0 {
+		update["Departed"] = firestore.Increment(d.departed)
+	}
+	for field, counts := range map[string]map[string]int{"BySource": d.bySource, "ByAbrigo": d.byAbrigo} {
+		increments := map[string]interface{}{}
+		for key, n := range counts {
+			if n != 0 {
+				increments[key] = firestore.Increment(n)
+			}
+		}
+		if len(increments) > 0 {
+			update[field] = increments
+		}
+	}
+	return tx.Set(doc, update, firestore.MergeAll)
+}
+
+func counterKey(key string) string {
+	if key == "" {
+		return UnknownCounterKey
+	}
+	return key
+}
+
+// FetchPessoaCounters returns the counters of the stored records, or nil when they
+// were never written.
+func FetchPessoaCounters(ctx context.Context) (*objects.PessoaCounters, error) {
+	defer metrics.ObserveBackend("firestore", "FetchPessoaCounters", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return nil, err
+	}
+	defer client.Close()
+
+	doc, err := client.Collection(Counters).Doc(PessoaCounters).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to retrieve counters", "error", err)
+		return nil, err
+	}
+	var counters objects.PessoaCounters
+	if err := doc.DataTo(&counters); err != nil {
+		slog.ErrorContext(ctx, "Failed to read counters", "error", err)
+		return nil, err
+	}
+	return &counters, nil
+}
+
+// RebuildPessoaCounters counts every stored record and replaces the counters. Writes
+// made while it runs may be lost, so it should run under the scrape lock.
+func RebuildPessoaCounters(ctx context.Context) (*objects.PessoaCounters, error) {
+	defer metrics.ObserveBackend("firestore", "RebuildPessoaCounters", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return nil, err
+	}
+	defer client.Close()
+
+	delta := newCounterDelta()
+	iter := client.Collection(PessoasAbrigos).Select("SheetId", "Abrigo", "Departed").Documents(ctx)
+	defer iter.Stop()
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to retrieve documents", "error", err)
+			return nil, err
+		}
+		delta.add(pessoaFromData(doc.Data()), 1)
+	}
+
+	now := time.Now()
+	counters := &objects.PessoaCounters{
+		Total:     delta.total,
+		Departed:  delta.departed,
+		BySource:  delta.bySource,
+		ByAbrigo:  delta.byAbrigo,
+		UpdatedAt: now,
+		RebuiltAt: &now,
+	}
+	if _, err := client.Collection(Counters).Doc(PessoaCounters).Set(ctx, counters); err != nil {
+		slog.ErrorContext(ctx, "Failed to write counters", "error", err)
+		return nil, err
+	}
+	return counters, nil
+}
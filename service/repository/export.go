@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"refugio/metrics"
+	"refugio/objects"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+)
+
+// ForEachPessoaPage reads the records selected by the filter a page at a time, in
+// the order they were first stored, and calls fn with each page. Only one page is held
+// in memory. Filtering by a field needs a composite index of the field and Timestamp.
+//
+// Timestamp is when the record was first stored, the same as Created() which the
+// statistics count by, and unlike CreatedAt every record has it.
+func ForEachPessoaPage(ctx context.Context, filter objects.PessoaFilter, pageSize int, fn func([]*objects.PessoaResult) error) error {
+	defer metrics.ObserveBackend("firestore", "ForEachPessoaPage", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return err
+	}
+	defer client.Close()
+
+	query := client.Collection(PessoasAbrigos).Query
+	for _, equal := range []struct{ field, value string }{{"SheetId", filter.SheetId}, {"Abrigo", filter.Abrigo}, {"Cidade", filter.Cidade}} {
+		if equal.value != "" {
+			query = query.Where(equal.field, "==", equal.value)
+		}
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("Timestamp", ">=", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("Timestamp", "<", filter.To)
+	}
+	query = query.OrderBy("Timestamp", firestore.Asc).OrderBy(firestore.DocumentID, firestore.Asc).Limit(pageSize)
+
+	var last *firestore.DocumentSnapshot
+	for {
+		page := query
+		if last != nil {
+			page = page.StartAfter(last)
+		}
+		docs, err := readPage(ctx, page)
+		if err != nil {
+			return err
+		}
+		if len(docs) == 0 {
+			return nil
+		}
+
+		pessoas := make([]*objects.PessoaResult, 0, len(docs))
+		for _, doc := range docs {
+			pessoas = append(pessoas, pessoaFromData(doc.Data()))
+		}
+		if err := fn(pessoas); err != nil {
+			return err
+		}
+		if len(docs) < pageSize {
+			return nil
+		}
+		last = docs[len(docs)-1]
+	}
+}
+
+func readPage(ctx context.Context, query firestore.Query) ([]*firestore.DocumentSnapshot, error) {
+	iter := query.Documents(ctx)
+	defer iter.Stop()
+	var docs []*firestore.DocumentSnapshot
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			return docs, nil
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to retrieve documents", "error", err)
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+}
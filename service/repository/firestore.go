@@ -3,13 +3,20 @@ package repository
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"os"
+	"refugio/metrics"
 	"refugio/objects"
+	"refugio/tracing"
 	"refugio/utils"
 	"time"
 
 	"cloud.google.com/go/firestore"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 /* Firestore collections */
@@ -17,66 +24,174 @@ const (
 	PessoasAbrigos = "PessoasAbrigos"
 	Sources        = "Sources"
 	Filters        = "Filters"
+	Locks          = "Locks"
+	ScrapeRuns     = "ScrapeRuns"
+	ApiKeys        = "ApiKeys"
+	RateLimits     = "RateLimits"
+	Counters       = "Counters"
+	StatsDays      = "StatsDays"
+	Takedowns      = "Takedowns"
+	Suppressions   = "Suppressions"
+	CachePurges    = "CachePurges"
 )
 
-var (
-	err    error
-	client *firestore.Client
+/* ScrapeRuns documents */
+const (
+	LastScrapeRun        = "last"
+	LastSuccessScrapeRun = "lastSuccess"
+	SourceScrapeTimes    = "sources"
 )
 
+// GetAll calls are split in batches to keep each request small
+const getAllBatchSize = 300
+
 func createClient(ctx context.Context) (*firestore.Client, error) {
 	if os.Getenv("ENVIRONMENT") == "local" {
 		serviceAccJSON := utils.GetServiceAccountJSON(os.Getenv("APP_SERVICE_ACCOUNT_JSON"))
-		client, err = firestore.NewClient(ctx, os.Getenv("FIRESTORE_PROJECT_ID"), option.WithCredentialsJSON(serviceAccJSON))
-	} else {
-		client, err = firestore.NewClient(ctx, os.Getenv("FIRESTORE_PROJECT_ID"))
+		return firestore.NewClient(ctx, os.Getenv("FIRESTORE_PROJECT_ID"), option.WithCredentialsJSON(serviceAccJSON))
 	}
-	return client, err
+	return firestore.NewClient(ctx, os.Getenv("FIRESTORE_PROJECT_ID"))
 }
 
-func AddPessoasToFirestore(pessoas []*objects.PessoaResult) error {
-	ctx := context.Background()
-	client, err = createClient(ctx)
-
+// Ping checks that Firestore answers, reading a document that may not exist.
+func Ping(ctx context.Context) error {
+	defer metrics.ObserveBackend("firestore", "Ping", time.Now())
+	client, err := createClient(ctx)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
-		client.Close()
 		return err
 	}
 	defer client.Close()
 
-	bulkWriter := client.BulkWriter(ctx)
+	_, err = client.Collection(ScrapeRuns).Doc(LastScrapeRun).Get(ctx)
+	if err != nil && status.Code(err) != codes.NotFound {
+		return err
+	}
+	return nil
+}
 
-	collection := client.Collection(PessoasAbrigos)
-	fmt.Fprintf(os.Stdout, "Adding %d documents to Firestore collection %v\n", len(pessoas), collection.Path)
-	jobs := make([]*firestore.BulkWriterJob, 0, len(pessoas))
+// getAll reads documents by reference inside their own span.
+func getAll(ctx context.Context, client *firestore.Client, refs []*firestore.DocumentRef) ([]*firestore.DocumentSnapshot, error) {
+	ctx, span := tracing.Start(ctx, "firestore.GetAll", attribute.Int("firestore.documents", len(refs)))
+	docs, err := client.GetAll(ctx, refs)
+	tracing.End(span, err)
+	return docs, err
+}
+
+// AddPessoasToFirestore writes the given PessoaResults and returns the AggregateKeys of
+// the ones written. Each batch is written in a transaction that also appends a revision
+// to every record and updates the counters. The batches that fail are logged and left
+// out of the keys, and the error of the last one is returned after the others are written.
+func AddPessoasToFirestore(ctx context.Context, pessoas []*objects.PessoaResult) ([]string, error) {
+	defer metrics.ObserveBackend("firestore", "AddPessoasToFirestore", time.Now())
+	client, err := createClient(ctx)
+
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return nil, err
+	}
+	defer client.Close()
+
+	// A document can only be written once per transaction, the last one wins
+	byKey := make(map[string]*objects.PessoaResult, len(pessoas))
+	keys := make([]string, 0, len(pessoas))
 	for _, pessoa := range pessoas {
-		doc := collection.Doc(pessoa.AggregateKey())
-		job, err := bulkWriter.Set(doc, &pessoa)
+		key := pessoa.AggregateKey()
+		if _, ok := byKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		byKey[key] = pessoa
+	}
+
+	collection := client.Collection(PessoasAbrigos)
+	counters := client.Collection(Counters).Doc(PessoaCounters)
+	slog.InfoContext(ctx, "Adding documents to Firestore", "collection", collection.Path, "count", len(keys))
+	actor := ActorFrom(ctx)
+	written := make([]string, 0, len(keys))
+	var failed error
+	for start := 0; start < len(keys); start += pessoasPerTransaction {
+		batch := keys[start:min(start+pessoasPerTransaction, len(keys))]
+		refs := make([]*firestore.DocumentRef, 0, len(batch))
+		for _, key := range batch {
+			refs = append(refs, collection.Doc(key))
+		}
+
+		err := client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+			docs, err := tx.GetAll(refs)
+			if err != nil {
+				return err
+			}
+			delta := newCounterDelta()
+			now := time.Now()
+			for i, doc := range docs {
+				pessoa := byKey[batch[i]]
+				revision := &objects.Revision{Action: objects.RevisionInsert, Actor: actor, After: pessoa, Timestamp: now}
+				if doc.Exists() {
+					revision.Action = objects.RevisionUpdate
+					revision.Before = pessoaFromData(doc.Data())
+					delta.add(revision.Before, -1)
+					// The update replaces the document, but not when the record was first written.
+					// Finding it changed confirms it as much as finding it unchanged.
+					pessoa.Timestamp = revision.Before.Timestamp
+					pessoa.CreatedAt = revision.Before.Created()
+					pessoa.LastSeen = now
+				} else {
+					// Timestamp is the first write time too, the exports select by it
+					pessoa.Timestamp = now
+					pessoa.CreatedAt = now
+				}
+				pessoa.UpdatedAt = now
+				delta.add(pessoa, 1)
+				if err := tx.Set(refs[i], pessoa); err != nil {
+					return err
+				}
+				if err := addRevision(tx, refs[i], revision); err != nil {
+					return err
+				}
+			}
+			return delta.apply(tx, counters)
+		})
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to create job: %v\n", err)
-			return err
+			slog.ErrorContext(ctx, "Failed to write documents", "count", len(batch), "error", err)
+			failed = err
+			continue
 		}
-		jobs = append(jobs, job)
+		written = append(written, batch...)
 	}
+	return written, failed
+}
 
-	bulkWriter.End()
-	for _, i := range jobs {
-		_, err := i.Results()
+// FetchPessoaKeys lists the AggregateKeys of every stored record, without reading them.
+func FetchPessoaKeys(ctx context.Context) ([]string, error) {
+	defer metrics.ObserveBackend("firestore", "FetchPessoaKeys", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return nil, err
+	}
+	defer client.Close()
+
+	var keys []string
+	refs := client.Collection(PessoasAbrigos).DocumentRefs(ctx)
+	for {
+		ref, err := refs.Next()
+		if err == iterator.Done {
+			break
+		}
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to get job results: %v\n", err)
+			slog.ErrorContext(ctx, "Failed to list documents", "error", err)
+			return nil, err
 		}
+		keys = append(keys, ref.ID)
 	}
-	return nil
+	return keys, nil
 }
 
-func FetchPessoaFromFirestore(docIDs []string) ([]*objects.PessoaResult, error) {
-	ctx := context.Background()
-	client, err = createClient(ctx)
+func FetchPessoaFromFirestore(ctx context.Context, docIDs []string) ([]*objects.PessoaResult, error) {
+	defer metrics.ObserveBackend("firestore", "FetchPessoaFromFirestore", time.Now())
+	client, err := createClient(ctx)
 
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
-		client.Close()
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
 		return nil, err
 	}
 	defer client.Close()
@@ -88,9 +203,9 @@ func FetchPessoaFromFirestore(docIDs []string) ([]*objects.PessoaResult, error)
 		refs = append(refs, pessoas.Doc(id))
 	}
 
-	docs, err := client.GetAll(ctx, refs)
+	docs, err := getAll(ctx, client, refs)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to retrieve documents: %v\n", err)
+		slog.ErrorContext(ctx, "Failed to retrieve documents", "error", err)
 	}
 
 	var results []*objects.PessoaResult
@@ -98,41 +213,270 @@ func FetchPessoaFromFirestore(docIDs []string) ([]*objects.PessoaResult, error)
 		if doc.Exists() {
 			var data map[string]interface{}
 			if err := doc.DataTo(&data); err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to read document: %v\n", err)
+				slog.ErrorContext(ctx, "Failed to read document", "error", err)
 			}
-			sheetId, ok := data["SheetId"].(string)
-			if !ok {
-				sheetId = ""
+			results = append(results, pessoaFromData(data))
+		} else {
+			slog.WarnContext(ctx, "Document does not exist", "id", doc.Ref.ID)
+		}
+	}
+
+	return results, nil
+}
+
+// FetchPessoasByKeys returns the stored PessoaResults for the given AggregateKeys.
+// Keys that are not stored are left out of the map.
+func FetchPessoasByKeys(ctx context.Context, keys []string) (map[string]*objects.PessoaResult, error) {
+	defer metrics.ObserveBackend("firestore", "FetchPessoasByKeys", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return nil, err
+	}
+	defer client.Close()
+
+	pessoas := client.Collection(PessoasAbrigos)
+	results := make(map[string]*objects.PessoaResult, len(keys))
+	for start := 0; start < len(keys); start += getAllBatchSize {
+		end := min(start+getAllBatchSize, len(keys))
+		refs := make([]*firestore.DocumentRef, 0, end-start)
+		for _, key := range keys[start:end] {
+			refs = append(refs, pessoas.Doc(key))
+		}
+
+		docs, err := getAll(ctx, client, refs)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to retrieve documents", "error", err)
+			return nil, err
+		}
+		for _, doc := range docs {
+			if !doc.Exists() {
+				continue
 			}
-			url, ok := data["URL"].(string)
-			if !ok {
-				url = ""
+			var data map[string]interface{}
+			if err := doc.DataTo(&data); err != nil {
+				slog.ErrorContext(ctx, "Failed to read document", "error", err)
+				continue
 			}
-			results = append(results, &objects.PessoaResult{
-				Pessoa: &objects.Pessoa{
-					Nome:   data["Nome"].(string),
-					Abrigo: data["Abrigo"].(string),
-					Idade:  data["Idade"].(string),
-					Observacao: data["Observacao"].(string),
-				},
-				SheetId:   &sheetId,
-				URL:       &url,
-				Timestamp: data["Timestamp"].(time.Time),
-			})
-		} else {
-			fmt.Fprintln(os.Stderr, "Document does not exist")
+			results[doc.Ref.ID] = pessoaFromData(data)
 		}
 	}
+	return results, nil
+}
 
+// FetchPessoasByOrigin returns every stored PessoaResult a scrape read from the ranges
+// of a spreadsheet, keyed by AggregateKey.
+func FetchPessoasByOrigin(ctx context.Context, sheetId string) (map[string]*objects.PessoaResult, error) {
+	defer metrics.ObserveBackend("firestore", "FetchPessoasByOrigin", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return nil, err
+	}
+	defer client.Close()
+
+	docs, err := client.Collection(PessoasAbrigos).Where("OriginSheetId", "==", sheetId).Documents(ctx).GetAll()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to retrieve documents", "error", err)
+		return nil, err
+	}
+
+	results := make(map[string]*objects.PessoaResult, len(docs))
+	for _, doc := range docs {
+		var data map[string]interface{}
+		if err := doc.DataTo(&data); err != nil {
+			slog.ErrorContext(ctx, "Failed to read document", "error", err)
+			continue
+		}
+		results[doc.Ref.ID] = pessoaFromData(data)
+	}
 	return results, nil
 }
 
-func AddSourcesToFirestore(sources []*objects.Source) error {
-	ctx := context.Background()
-	client, err = createClient(ctx)
+func MarkPessoasDeparted(ctx context.Context, keys []string) error {
+	defer metrics.ObserveBackend("firestore", "MarkPessoasDeparted", time.Now())
+	slog.InfoContext(ctx, "Marking documents as departed in Firestore", "collection", PessoasAbrigos, "count", len(keys))
+	actor := ActorFrom(ctx)
+	return updatePessoas(ctx, keys, func(tx *firestore.Transaction, doc *firestore.DocumentSnapshot, stored *objects.PessoaResult, delta *counterDelta) error {
+		if stored.Departed {
+			return nil
+		}
+		delta.departed++
+		if err := tx.Update(doc.Ref, []firestore.Update{{Path: "Departed", Value: true}}); err != nil {
+			return err
+		}
+		after := *stored
+		after.Departed = true
+		return addRevision(tx, doc.Ref, &objects.Revision{Action: objects.RevisionDeparted, Actor: actor, Before: stored, After: &after, Timestamp: time.Now()})
+	})
+}
+
+// DeletePessoasFromFirestore deletes the records with the given AggregateKeys and
+// takes them out of the counters. Keys that are not stored are skipped, the history of
+// the deleted records is kept.
+func DeletePessoasFromFirestore(ctx context.Context, keys []string) error {
+	defer metrics.ObserveBackend("firestore", "DeletePessoasFromFirestore", time.Now())
+	slog.InfoContext(ctx, "Deleting documents from Firestore", "collection", PessoasAbrigos, "count", len(keys))
+	actor := ActorFrom(ctx)
+	return updatePessoas(ctx, keys, func(tx *firestore.Transaction, doc *firestore.DocumentSnapshot, stored *objects.PessoaResult, delta *counterDelta) error {
+		delta.add(stored, -1)
+		if err := tx.Delete(doc.Ref); err != nil {
+			return err
+		}
+		return addRevision(tx, doc.Ref, &objects.Revision{Action: objects.RevisionDelete, Actor: actor, Before: stored, Timestamp: time.Now()})
+	})
+}
+
+// PurgePessoasFromFirestore deletes the records with the given AggregateKeys as
+// DeletePessoasFromFirestore does, and their history with them. The history is deleted
+// even for the records whose delete failed, as they are being purged.
+func PurgePessoasFromFirestore(ctx context.Context, keys []string) error {
+	defer metrics.ObserveBackend("firestore", "PurgePessoasFromFirestore", time.Now())
+	deleteErr := DeletePessoasFromFirestore(ctx, keys)
+
+	client, err := createClient(ctx)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
-		client.Close()
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return err
+	}
+	defer client.Close()
+
+	slog.InfoContext(ctx, "Deleting revisions from Firestore", "collection", PessoasAbrigos, "count", len(keys))
+	if err := deleteRevisions(ctx, client, keys); err != nil {
+		return err
+	}
+	return deleteErr
+}
+
+// TouchPessoas sets the LastSeen of the stored records the scrape found unchanged, and
+// the origin they were found in when it is known. It only tells that the record is still
+// in its source, so no revision is added. Records that are not stored are skipped.
+func TouchPessoas(ctx context.Context, pessoas []*objects.PessoaResult, seenAt time.Time) error {
+	defer metrics.ObserveBackend("firestore", "TouchPessoas", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return err
+	}
+	defer client.Close()
+
+	bulkWriter := client.BulkWriter(ctx)
+	collection := client.Collection(PessoasAbrigos)
+	jobs := make([]*firestore.BulkWriterJob, 0, len(pessoas))
+	for _, pessoa := range pessoas {
+		updates := []firestore.Update{{Path: "LastSeen", Value: seenAt}}
+		// Records stored before the origin was kept get it on their first scrape
+		if pessoa.OriginSheetId != "" {
+			updates = append(updates, firestore.Update{Path: "OriginSheetId", Value: pessoa.OriginSheetId}, firestore.Update{Path: "OriginRange", Value: pessoa.OriginRange})
+		}
+		job, err := bulkWriter.Update(collection.Doc(pessoa.AggregateKey()), updates)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to queue document update", "error", err)
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	bulkWriter.End()
+
+	var failed error
+	failedCount := 0
+	for _, job := range jobs {
+		if _, err := job.Results(); err != nil && status.Code(err) != codes.NotFound {
+			failed = err
+			failedCount++
+		}
+	}
+	if failed != nil {
+		slog.ErrorContext(ctx, "Failed to update last seen time", "count", failedCount, "error", failed)
+	}
+	return failed
+}
+
+// updatePessoas calls change for every stored record of the keys, in transactions that
+// apply the counter changes along with the records.
+func updatePessoas(ctx context.Context, keys []string, change func(tx *firestore.Transaction, doc *firestore.DocumentSnapshot, stored *objects.PessoaResult, delta *counterDelta) error) error {
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return err
+	}
+	defer client.Close()
+
+	collection := client.Collection(PessoasAbrigos)
+	counters := client.Collection(Counters).Doc(PessoaCounters)
+	var failed error
+	for start := 0; start < len(keys); start += pessoasPerTransaction {
+		refs := make([]*firestore.DocumentRef, 0, pessoasPerTransaction)
+		for _, key := range keys[start:min(start+pessoasPerTransaction, len(keys))] {
+			refs = append(refs, collection.Doc(key))
+		}
+
+		err := client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+			docs, err := tx.GetAll(refs)
+			if err != nil {
+				return err
+			}
+			delta := newCounterDelta()
+			for _, doc := range docs {
+				if !doc.Exists() {
+					continue
+				}
+				if err := change(tx, doc, pessoaFromData(doc.Data()), delta); err != nil {
+					return err
+				}
+			}
+			return delta.apply(tx, counters)
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to update documents", "count", len(refs), "error", err)
+			failed = err
+		}
+	}
+	return failed
+}
+
+func pessoaFromData(data map[string]interface{}) *objects.PessoaResult {
+	nome, _ := data["Nome"].(string)
+	abrigo, _ := data["Abrigo"].(string)
+	idade, _ := data["Idade"].(string)
+	observacao, _ := data["Observacao"].(string)
+	sheetId, _ := data["SheetId"].(string)
+	url, _ := data["URL"].(string)
+	cidade, _ := data["Cidade"].(string)
+	timestamp, _ := data["Timestamp"].(time.Time)
+	createdAt, _ := data["CreatedAt"].(time.Time)
+	updatedAt, _ := data["UpdatedAt"].(time.Time)
+	lastSeen, _ := data["LastSeen"].(time.Time)
+	departed, _ := data["Departed"].(bool)
+	originSheetId, _ := data["OriginSheetId"].(string)
+	originRange, _ := data["OriginRange"].(string)
+
+	return &objects.PessoaResult{
+		Pessoa: &objects.Pessoa{
+			Nome:       nome,
+			Abrigo:     abrigo,
+			Idade:      idade,
+			Observacao: observacao,
+		},
+		SheetId:   &sheetId,
+		URL:       &url,
+		Cidade:    cidade,
+		Timestamp: timestamp,
+		UpdatedAt: updatedAt,
+		CreatedAt: createdAt,
+		LastSeen:  lastSeen,
+		Departed:  departed,
+
+		OriginSheetId: originSheetId,
+		OriginRange:   originRange,
+	}
+}
+
+func AddSourcesToFirestore(ctx context.Context, sources []*objects.Source) error {
+	defer metrics.ObserveBackend("firestore", "AddSourcesToFirestore", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
 		return err
 	}
 	defer client.Close()
@@ -140,23 +484,43 @@ func AddSourcesToFirestore(sources []*objects.Source) error {
 	bulkWriter := client.BulkWriter(ctx)
 
 	collection := client.Collection(Sources)
-	fmt.Fprintf(os.Stdout, "Adding %d documents to Firestore collection %v\n", len(sources), collection.Path)
+	slog.InfoContext(ctx, "Adding documents to Firestore", "collection", collection.Path, "count", len(sources))
 	for _, source := range sources {
-		doc := collection.Doc(source.URL + source.SheetId)
-		bulkWriter.Set(doc, &source)
+		// Merged, so that the health of the source is kept
+		data := map[string]interface{}{
+			"Nome":        source.Nome,
+			"SheetId":     source.SheetId,
+			"URL":         source.URL,
+			"Observacao":  source.Observacao,
+			"Sheets":      source.Sheets,
+			"Configured":  source.Configured,
+			"Organizacao": source.Organizacao,
+			"Contato":     source.Contato,
+			"Cidade":      source.Cidade,
+			"Regiao":      source.Regiao,
+		}
+		// The times that are not known this time keep their stored value
+		if source.ModifiedAt != nil {
+			data["ModifiedAt"] = *source.ModifiedAt
+		}
+		if source.ScrapedAt != nil {
+			data["ScrapedAt"] = *source.ScrapedAt
+		}
+		bulkWriter.Set(collection.Doc(sourceDocId(source)), data, firestore.MergeAll)
 	}
 
 	bulkWriter.End()
 	return nil
 }
 
-func FetchSourcesFromFirestore() ([]*objects.Source, error) {
-	ctx := context.Background()
-	client, err = createClient(ctx)
+// FetchSourcesFromFirestore returns the stored sources. A source missing a field is
+// read with it empty, the documents that cannot be read are skipped.
+func FetchSourcesFromFirestore(ctx context.Context) ([]*objects.Source, error) {
+	defer metrics.ObserveBackend("firestore", "FetchSourcesFromFirestore", time.Now())
+	client, err := createClient(ctx)
 
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error creating client: %v", err)
-		client.Close()
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
 		return nil, err
 	}
 	defer client.Close()
@@ -165,7 +529,8 @@ func FetchSourcesFromFirestore() ([]*objects.Source, error) {
 	docs, err := sources.Documents(ctx).GetAll()
 
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to retrieve documents: %v", err)
+		slog.ErrorContext(ctx, "Failed to retrieve documents", "error", err)
+		return nil, err
 	}
 
 	var results []*objects.Source
@@ -173,12 +538,12 @@ func FetchSourcesFromFirestore() ([]*objects.Source, error) {
 		if doc.Exists() {
 			var data map[string]interface{}
 			if err := doc.DataTo(&data); err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to read document: %v", err)
+				slog.ErrorContext(ctx, "Failed to read document", "id", doc.Ref.ID, "error", err)
+				continue
 			}
 
-			
 			sheetsInterface, _ := data["Sheets"].([]interface{})
-			
+
 			sheets := make([]string, len(sheetsInterface))
 			for i, v := range sheetsInterface {
 				sheets[i], _ = v.(string)
@@ -189,27 +554,136 @@ func FetchSourcesFromFirestore() ([]*objects.Source, error) {
 				observacao = ""
 			}
 
+			var stored struct {
+				Health     objects.SourceHealth
+				ModifiedAt *time.Time
+				ScrapedAt  *time.Time
+			}
+			if err := doc.DataTo(&stored); err != nil {
+				slog.ErrorContext(ctx, "Failed to read source times and health", "id", doc.Ref.ID, "error", err)
+			}
+			nome, _ := data["Nome"].(string)
+			url, _ := data["URL"].(string)
+			sheetId, _ := data["SheetId"].(string)
+			configured, _ := data["Configured"].(bool)
+			organizacao, _ := data["Organizacao"].(string)
+			contato, _ := data["Contato"].(string)
+			cidade, _ := data["Cidade"].(string)
+			regiao, _ := data["Regiao"].(string)
+
 			results = append(results, &objects.Source{
-				Nome:       data["Nome"].(string),
-				URL:        data["URL"].(string),
-				SheetId:    data["SheetId"].(string),
+				Nome:       nome,
+				URL:        url,
+				SheetId:    sheetId,
 				Observacao: observacao,
 				Sheets:     sheets,
+				// The configured sources were stored without an URL before they were told apart
+				Configured:  configured || url == "",
+				Organizacao: organizacao,
+				Contato:     contato,
+				Cidade:      cidade,
+				Regiao:      regiao,
+				ModifiedAt:  stored.ModifiedAt,
+				ScrapedAt:   stored.ScrapedAt,
+				Health:      stored.Health,
 			})
 		} else {
-			fmt.Fprintln(os.Stderr, "Document does not exist")
+			slog.WarnContext(ctx, "Document does not exist", "id", doc.Ref.ID)
 		}
 	}
 	return results, nil
 }
 
-func FetchFilterFromFirestore(key string) ([]byte, error) {
-	ctx := context.Background()
-	client, err = createClient(ctx)
+// sourceDocId is the id of the document of the source, its spreadsheet id for the
+// configured sources.
+func sourceDocId(source *objects.Source) string {
+	if source.Configured {
+		return source.SheetId
+	}
+	return source.URL + source.SheetId
+}
+
+// RecordSourceScrape adds the outcome of a scrape to the health of a configured
+// source, readErr being nil when every range was read. It returns the health as it was
+// left and whether the source was disabled by this failure.
+func RecordSourceScrape(ctx context.Context, source *objects.Source, readErr error, maxFailures int) (objects.SourceHealth, bool, error) {
+	defer metrics.ObserveBackend("firestore", "RecordSourceScrape", time.Now())
+	var disabled bool
+	health, err := updateSourceHealth(ctx, source, func(health *objects.SourceHealth) error {
+		disabled = health.Record(readErr, time.Now(), maxFailures)
+		return nil
+	})
+	return health, disabled && err == nil, err
+}
+
+// SetSourceDisabled disables or enables a configured source by hand. Enabling it
+// clears its failures, so that it gets as many tries as a new source.
+func SetSourceDisabled(ctx context.Context, source *objects.Source, disabled bool) (objects.SourceHealth, error) {
+	defer metrics.ObserveBackend("firestore", "SetSourceDisabled", time.Now())
+	return updateSourceHealth(ctx, source, func(health *objects.SourceHealth) error {
+		if !disabled {
+			health.Failures = 0
+			health.Disabled = false
+			health.DisabledAt = nil
+		} else if !health.Disabled {
+			now := time.Now()
+			health.Disabled = true
+			health.DisabledAt = &now
+		}
+		return nil
+	})
+}
+
+// updateSourceHealth changes the health of the source in a transaction. The source
+// document is created when the source was never stored, a source whose spreadsheet
+// could never be read still shows up with its health.
+func updateSourceHealth(ctx context.Context, source *objects.Source, change func(health *objects.SourceHealth) error) (objects.SourceHealth, error) {
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return objects.SourceHealth{}, err
+	}
+	defer client.Close()
+
+	doc := client.Collection(Sources).Doc(sourceDocId(source))
+	var health objects.SourceHealth
+	err = client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		docSnap, err := tx.Get(doc)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		var stored struct{ Health objects.SourceHealth }
+		if docSnap.Exists() {
+			if err := docSnap.DataTo(&stored); err != nil {
+				return err
+			}
+		}
+		health = stored.Health
+		if err := change(&health); err != nil {
+			return err
+		}
+		data := map[string]interface{}{"Health": health}
+		if !docSnap.Exists() {
+			data["Nome"] = source.Nome
+			data["SheetId"] = source.SheetId
+			data["URL"] = source.URL
+			data["Configured"] = source.Configured
+		}
+		return tx.Set(doc, data, firestore.MergeAll)
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to update source health", "sheet_id", source.SheetId, "error", err)
+		return objects.SourceHealth{}, err
+	}
+	return health, nil
+}
+
+func FetchFilterFromFirestore(ctx context.Context, key string) ([]byte, error) {
+	defer metrics.ObserveBackend("firestore", "FetchFilterFromFirestore", time.Now())
+	client, err := createClient(ctx)
 
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
-		client.Close()
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
 		return nil, err
 	}
 	defer client.Close()
@@ -217,52 +691,55 @@ func FetchFilterFromFirestore(key string) ([]byte, error) {
 	filterCollection := client.Collection(Filters)
 	doc := filterCollection.Doc(key)
 	docSnap, err := doc.Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, err
+	}
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to retrieve document: %v\n", err)
+		slog.ErrorContext(ctx, "Failed to retrieve document", "error", err)
 		return nil, err
 	}
 	var data map[string][]byte
 	if err := docSnap.DataTo(&data); err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to read document: %v\n", err)
+		slog.ErrorContext(ctx, "Failed to read document", "error", err)
+		return nil, err
 	}
 
 	if filter, ok := data["filter"]; !ok {
-		fmt.Fprintf(os.Stderr, "Filter not found in document\n")
+		slog.ErrorContext(ctx, "Filter not found in document", "filter", key)
 		return nil, fmt.Errorf("filter not found in document")
 	} else {
 		return filter, nil
 	}
 }
 
-func UpdateFilterOnFirestore(key string, data []byte) error {
-	ctx := context.Background()
-	client, err = createClient(ctx)
+func UpdateFilterOnFirestore(ctx context.Context, key string, data []byte) error {
+	defer metrics.ObserveBackend("firestore", "UpdateFilterOnFirestore", time.Now())
+	client, err := createClient(ctx)
 
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
-		client.Close()
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
 		return err
 	}
 	defer client.Close()
 
 	filterCollection := client.Collection(Filters)
 	doc := filterCollection.Doc(key)
-	_, err := doc.Set(ctx, map[string][]byte{"filter": data})
+	_, err = doc.Set(ctx, map[string][]byte{"filter": data})
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to update document: %v\n", err)
+		slog.ErrorContext(ctx, "Failed to update document", "error", err)
 		return err
 	}
-	fmt.Fprintf(os.Stdout, "Filter %s updated successfully\n", key)
+	slog.InfoContext(ctx, "Filter updated", "filter", key)
 	return nil
 }
 
-func FetchMostRecent(key string) (*time.Time, error) {
-	ctx := context.Background()
-	client, err = createClient(ctx)
+func FetchMostRecent(ctx context.Context, key string) (*time.Time, error) {
+	defer metrics.ObserveBackend("firestore", "FetchMostRecent", time.Now())
+	client, err := createClient(ctx)
 
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
-		client.Close()
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return nil, err
 	}
 	defer client.Close()
 
@@ -270,7 +747,7 @@ func FetchMostRecent(key string) (*time.Time, error) {
 	query := collection.Query.OrderBy("Timestamp", firestore.Desc).Limit(1)
 	docs, err := query.Documents(ctx).GetAll()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to retrieve documents: %v\n", err)
+		slog.ErrorContext(ctx, "Failed to retrieve documents", "error", err)
 		return nil, err
 	}
 
@@ -278,10 +755,10 @@ func FetchMostRecent(key string) (*time.Time, error) {
 		if doc.Exists() {
 			var data map[string]interface{}
 			if err := doc.DataTo(&data); err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to read document: %v\n", err)
+				slog.ErrorContext(ctx, "Failed to read document", "error", err)
 			}
 			if timestamp, ok := data["Timestamp"].(time.Time); !ok {
-				fmt.Fprintf(os.Stderr, "Timestamp not found in document\n")
+				slog.ErrorContext(ctx, "Timestamp not found in document", "id", doc.Ref.ID)
 				return nil, fmt.Errorf("timestamp not found in document")
 			} else {
 				return &timestamp, nil
@@ -290,3 +767,292 @@ func FetchMostRecent(key string) (*time.Time, error) {
 	}
 	return nil, err
 }
+
+// AcquireLock takes the named lock for owner until ttl expires. It returns false when
+// someone else holds an unexpired lock.
+func AcquireLock(ctx context.Context, name string, owner string, ttl time.Duration) (bool, error) {
+	defer metrics.ObserveBackend("firestore", "AcquireLock", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return false, err
+	}
+	defer client.Close()
+
+	doc := client.Collection(Locks).Doc(name)
+	acquired := false
+	err = client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		acquired = false
+		docSnap, err := tx.Get(doc)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		if docSnap.Exists() {
+			var lock objects.Lock
+			if err := docSnap.DataTo(&lock); err != nil {
+				return err
+			}
+			if lock.Owner != owner && lock.ExpiresAt.After(time.Now()) {
+				return nil
+			}
+		}
+		acquired = true
+		return tx.Set(doc, objects.Lock{Owner: owner, ExpiresAt: time.Now().Add(ttl)})
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to acquire lock", "lock", name, "error", err)
+		return false, err
+	}
+	return acquired, nil
+}
+
+func ReleaseLock(ctx context.Context, name string, owner string) error {
+	defer metrics.ObserveBackend("firestore", "ReleaseLock", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return err
+	}
+	defer client.Close()
+
+	doc := client.Collection(Locks).Doc(name)
+	err = client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		docSnap, err := tx.Get(doc)
+		if status.Code(err) == codes.NotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var lock objects.Lock
+		if err := docSnap.DataTo(&lock); err != nil {
+			return err
+		}
+		if lock.Owner != owner {
+			return nil
+		}
+		return tx.Delete(doc)
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to release lock", "lock", name, "error", err)
+	}
+	return err
+}
+
+func AddScrapeRunToFirestore(ctx context.Context, run *objects.ScrapeRun) error {
+	defer metrics.ObserveBackend("firestore", "AddScrapeRunToFirestore", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return err
+	}
+	defer client.Close()
+
+	batch := client.Batch()
+	collection := client.Collection(ScrapeRuns)
+	batch.Set(collection.Doc(LastScrapeRun), run)
+	if run.Success {
+		batch.Set(collection.Doc(LastSuccessScrapeRun), run)
+	}
+	if _, err := batch.Commit(ctx); err != nil {
+		slog.ErrorContext(ctx, "Failed to store scrape run", "error", err)
+		return err
+	}
+	return nil
+}
+
+// FetchScrapeRun returns one of the ScrapeRuns documents, LastScrapeRun or
+// LastSuccessScrapeRun. It returns nil when no run was stored yet.
+func FetchScrapeRun(ctx context.Context, key string) (*objects.ScrapeRun, error) {
+	defer metrics.ObserveBackend("firestore", "FetchScrapeRun", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return nil, err
+	}
+	defer client.Close()
+
+	docSnap, err := client.Collection(ScrapeRuns).Doc(key).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to retrieve document", "error", err)
+		return nil, err
+	}
+
+	var run objects.ScrapeRun
+	if err := docSnap.DataTo(&run); err != nil {
+		slog.ErrorContext(ctx, "Failed to read document", "error", err)
+		return nil, err
+	}
+	return &run, nil
+}
+
+// FetchSourceScrapeTimes returns when each spreadsheet was last scraped successfully.
+func FetchSourceScrapeTimes(ctx context.Context) (map[string]time.Time, error) {
+	defer metrics.ObserveBackend("firestore", "FetchSourceScrapeTimes", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return nil, err
+	}
+	defer client.Close()
+
+	docSnap, err := client.Collection(ScrapeRuns).Doc(SourceScrapeTimes).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return map[string]time.Time{}, nil
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to retrieve document", "error", err)
+		return nil, err
+	}
+
+	var times map[string]time.Time
+	if err := docSnap.DataTo(&times); err != nil {
+		slog.ErrorContext(ctx, "Failed to read document", "error", err)
+		return nil, err
+	}
+	return times, nil
+}
+
+func UpdateSourceScrapeTimes(ctx context.Context, sheetIds []string, scrapedAt time.Time) error {
+	defer metrics.ObserveBackend("firestore", "UpdateSourceScrapeTimes", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return err
+	}
+	defer client.Close()
+
+	times := make(map[string]interface{}, len(sheetIds))
+	for _, id := range sheetIds {
+		times[id] = scrapedAt
+	}
+	_, err = client.Collection(ScrapeRuns).Doc(SourceScrapeTimes).Set(ctx, times, firestore.MergeAll)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to update document", "error", err)
+	}
+	return err
+}
+
+func AddApiKeyToFirestore(ctx context.Context, key *objects.ApiKey) error {
+	defer metrics.ObserveBackend("firestore", "AddApiKeyToFirestore", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return err
+	}
+	defer client.Close()
+
+	_, err = client.Collection(ApiKeys).Doc(key.Id).Set(ctx, key)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to store api key", "error", err)
+	}
+	return err
+}
+
+// FetchApiKeyFromFirestore returns nil when there is no key with that id.
+func FetchApiKeyFromFirestore(ctx context.Context, id string) (*objects.ApiKey, error) {
+	defer metrics.ObserveBackend("firestore", "FetchApiKeyFromFirestore", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return nil, err
+	}
+	defer client.Close()
+
+	docSnap, err := client.Collection(ApiKeys).Doc(id).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to retrieve document", "error", err)
+		return nil, err
+	}
+
+	var key objects.ApiKey
+	if err := docSnap.DataTo(&key); err != nil {
+		slog.ErrorContext(ctx, "Failed to read document", "error", err)
+		return nil, err
+	}
+	return &key, nil
+}
+
+func FetchApiKeysFromFirestore(ctx context.Context) ([]*objects.ApiKey, error) {
+	defer metrics.ObserveBackend("firestore", "FetchApiKeysFromFirestore", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return nil, err
+	}
+	defer client.Close()
+
+	docs, err := client.Collection(ApiKeys).OrderBy("CreatedAt", firestore.Asc).Documents(ctx).GetAll()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to retrieve documents", "error", err)
+		return nil, err
+	}
+
+	keys := make([]*objects.ApiKey, 0, len(docs))
+	for _, doc := range docs {
+		var key objects.ApiKey
+		if err := doc.DataTo(&key); err != nil {
+			slog.ErrorContext(ctx, "Failed to read document", "error", err)
+			continue
+		}
+		keys = append(keys, &key)
+	}
+	return keys, nil
+}
+
+func TouchApiKey(ctx context.Context, id string, usedAt time.Time) error {
+	defer metrics.ObserveBackend("firestore", "TouchApiKey", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return err
+	}
+	defer client.Close()
+
+	_, err = client.Collection(ApiKeys).Doc(id).Update(ctx, []firestore.Update{{Path: "LastUsedAt", Value: usedAt}})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to update api key usage", "error", err)
+	}
+	return err
+}
+
+// TakeToken takes a token from a bucket shared by every instance. It returns whether
+// the token was taken and the bucket as it was left.
+func TakeToken(ctx context.Context, bucket string, limit objects.RateLimit, now time.Time) (bool, objects.TokenBucket, error) {
+	defer metrics.ObserveBackend("firestore", "TakeToken", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return false, objects.TokenBucket{}, err
+	}
+	defer client.Close()
+
+	doc := client.Collection(RateLimits).Doc(bucket)
+	var state objects.TokenBucket
+	var taken bool
+	err = client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		state = objects.TokenBucket{}
+		docSnap, err := tx.Get(doc)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		if docSnap.Exists() {
+			if err := docSnap.DataTo(&state); err != nil {
+				return err
+			}
+		}
+		taken = state.Take(limit, now)
+		return tx.Set(doc, state)
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to take rate limit token", "error", err)
+		return false, objects.TokenBucket{}, err
+	}
+	return taken, state, nil
+}
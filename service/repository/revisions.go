@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"refugio/metrics"
+	"refugio/objects"
+	"time"
+
+	"cloud.google.com/go/firestore"
+)
+
+// Revisions is the subcollection of every record with its history. It is kept when
+// the record is deleted, but for the takedowns and the retention purges, which also
+// reach the history.
+const Revisions = "Revisions"
+
+type contextKey string
+
+const actorContextKey contextKey = "actor"
+
+// WithActor sets who the writes made with the context are attributed to.
+func WithActor(ctx context.Context, actor objects.Actor) context.Context {
+	return context.WithValue(ctx, actorContextKey, actor)
+}
+
+// ActorFrom returns the actor set with WithActor.
+func ActorFrom(ctx context.Context) objects.Actor {
+	if actor, ok := ctx.Value(actorContextKey).(objects.Actor); ok {
+		return actor
+	}
+	return objects.Actor{Kind: objects.ActorUnknown}
+}
+
+// addRevision appends a revision to the history of the record inside the transaction.
+func addRevision(tx *firestore.Transaction, ref *firestore.DocumentRef, revision *objects.Revision) error {
+	return tx.Create(ref.Collection(Revisions).NewDoc(), revision)
+}
+
+// FetchRevisions returns the history of the record with the given AggregateKey, the
+// oldest revision first. It is empty for records that were never written.
+func FetchRevisions(ctx context.Context, key string) ([]*objects.Revision, error) {
+	defer metrics.ObserveBackend("firestore", "FetchRevisions", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return nil, err
+	}
+	defer client.Close()
+
+	docs, err := client.Collection(PessoasAbrigos).Doc(key).Collection(Revisions).OrderBy("Timestamp", firestore.Asc).Documents(ctx).GetAll()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to retrieve revisions", "key", key, "error", err)
+		return nil, err
+	}
+	revisions := make([]*objects.Revision, 0, len(docs))
+	for _, doc := range docs {
+		revisions = append(revisions, revisionFromData(doc.Data()))
+	}
+	return revisions, nil
+}
+
+// revisionFromData reads the records of the revision the same way as the stored ones.
+func revisionFromData(data map[string]interface{}) *objects.Revision {
+	revision := &objects.Revision{}
+	revision.Action, _ = data["Action"].(string)
+	revision.Timestamp, _ = data["Timestamp"].(time.Time)
+	revision.TakedownId, _ = data["TakedownId"].(string)
+	if actor, ok := data["Actor"].(map[string]interface{}); ok {
+		revision.Actor.Kind, _ = actor["Kind"].(string)
+		revision.Actor.Id, _ = actor["Id"].(string)
+	}
+	if before, ok := data["Before"].(map[string]interface{}); ok {
+		revision.Before = pessoaFromData(before)
+	}
+	if after, ok := data["After"].(map[string]interface{}); ok {
+		revision.After = pessoaFromData(after)
+	}
+	return revision
+}
+
+// rewriteRevisions calls change on every version of the records kept in their history
+// and stores the free text fields it leaves, the only ones a takedown changes.
+func rewriteRevisions(ctx context.Context, client *firestore.Client, keys []string, change func(pessoa *objects.PessoaResult)) error {
+	return writeRevisions(ctx, client, keys, func(bulkWriter *firestore.BulkWriter, doc *firestore.DocumentSnapshot) (*firestore.BulkWriterJob, error) {
+		revision := revisionFromData(doc.Data())
+		var updates []firestore.Update
+		for _, version := range []struct {
+			path   string
+			pessoa *objects.PessoaResult
+		}{{"Before", revision.Before}, {"After", revision.After}} {
+			if version.pessoa == nil {
+				continue
+			}
+			change(version.pessoa)
+			updates = append(updates,
+				firestore.Update{Path: version.path + ".Idade", Value: version.pessoa.Idade},
+				firestore.Update{Path: version.path + ".Observacao", Value: version.pessoa.Observacao})
+		}
+		if len(updates) == 0 {
+			return nil, nil
+		}
+		return bulkWriter.Update(doc.Ref, updates)
+	})
+}
+
+// deleteRevisions deletes the history of the records with the given AggregateKeys.
+func deleteRevisions(ctx context.Context, client *firestore.Client, keys []string) error {
+	return writeRevisions(ctx, client, keys, func(bulkWriter *firestore.BulkWriter, doc *firestore.DocumentSnapshot) (*firestore.BulkWriterJob, error) {
+		return bulkWriter.Delete(doc.Ref)
+	})
+}
+
+// writeRevisions queues the write returned by write for every revision of the records
+// with the given AggregateKeys, write returning no job when there is nothing to write.
+// It returns once the writes are done.
+func writeRevisions(ctx context.Context, client *firestore.Client, keys []string, write func(bulkWriter *firestore.BulkWriter, doc *firestore.DocumentSnapshot) (*firestore.BulkWriterJob, error)) error {
+	bulkWriter := client.BulkWriter(ctx)
+	collection := client.Collection(PessoasAbrigos)
+	var jobs []*firestore.BulkWriterJob
+	var failed error
+	for _, key := range keys {
+		docs, err := collection.Doc(key).Collection(Revisions).Documents(ctx).GetAll()
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to retrieve revisions", "key", key, "error", err)
+			failed = err
+			continue
+		}
+		for _, doc := range docs {
+			job, err := write(bulkWriter, doc)
+			if err != nil {
+				slog.ErrorContext(ctx, "Failed to queue revision write", "key", key, "error", err)
+				failed = err
+				continue
+			}
+			if job != nil {
+				jobs = append(jobs, job)
+			}
+		}
+	}
+	bulkWriter.End()
+
+	failedCount := 0
+	for _, job := range jobs {
+		if _, err := job.Results(); err != nil {
+			failed = err
+			failedCount++
+		}
+	}
+	if failedCount > 0 {
+		slog.ErrorContext(ctx, "Failed to write revisions", "count", failedCount, "error", failed)
+	}
+	return failed
+}
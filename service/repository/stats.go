@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"refugio/metrics"
+	"refugio/objects"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+)
+
+// FetchPessoasForStats reads the fields of every stored record that the statistics
+// are computed from.
+func FetchPessoasForStats(ctx context.Context) ([]*objects.PessoaResult, error) {
+	defer metrics.ObserveBackend("firestore", "FetchPessoasForStats", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return nil, err
+	}
+	defer client.Close()
+
+	var pessoas []*objects.PessoaResult
+	iter := client.Collection(PessoasAbrigos).Select("SheetId", "Abrigo", "Cidade", "Timestamp", "CreatedAt", "Departed").Documents(ctx)
+	defer iter.Stop()
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to retrieve documents", "error", err)
+			return nil, err
+		}
+		pessoas = append(pessoas, pessoaFromData(doc.Data()))
+	}
+	return pessoas, nil
+}
+
+// FetchPessoasCreatedBetween reads the fields the statistics are computed from of the
+// records first stored from from until to. The records stored before CreatedAt was
+// kept are found by their Timestamp.
+func FetchPessoasCreatedBetween(ctx context.Context, from time.Time, to time.Time) ([]*objects.PessoaResult, error) {
+	defer metrics.ObserveBackend("firestore", "FetchPessoasCreatedBetween", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return nil, err
+	}
+	defer client.Close()
+
+	byId := map[string]*objects.PessoaResult{}
+	for _, field := range []string{"CreatedAt", "Timestamp"} {
+		query := client.Collection(PessoasAbrigos).Select("SheetId", "Abrigo", "Cidade", "Timestamp", "CreatedAt", "Departed").
+			Where(field, ">=", from).Where(field, "<", to)
+		docs, err := query.Documents(ctx).GetAll()
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to retrieve documents", "error", err)
+			return nil, err
+		}
+		for _, doc := range docs {
+			byId[doc.Ref.ID] = pessoaFromData(doc.Data())
+		}
+	}
+
+	pessoas := make([]*objects.PessoaResult, 0, len(byId))
+	for _, pessoa := range byId {
+		if created := pessoa.Created(); !created.Before(from) && created.Before(to) {
+			pessoas = append(pessoas, pessoa)
+		}
+	}
+	return pessoas, nil
+}
+
+// UpdateStatsDays stores the days and deletes the stored ones among dates that are not
+// among them, leaving the other dates alone.
+func UpdateStatsDays(ctx context.Context, dates []string, days []*objects.StatsDay) error {
+	defer metrics.ObserveBackend("firestore", "UpdateStatsDays", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return err
+	}
+	defer client.Close()
+
+	collection := client.Collection(StatsDays)
+	empty := make(map[string]bool, len(dates))
+	for _, date := range dates {
+		empty[date] = true
+	}
+
+	bulkWriter := client.BulkWriter(ctx)
+	jobs := make([]*firestore.BulkWriterJob, 0, len(dates))
+	for _, day := range days {
+		delete(empty, day.Date)
+		job, err := bulkWriter.Set(collection.Doc(day.Date), day)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to create job", "error", err)
+			bulkWriter.End()
+			return err
+		}
+		jobs = append(jobs, job)
+	}
+	for date := range empty {
+		job, err := bulkWriter.Delete(collection.Doc(date))
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to create job", "error", err)
+			bulkWriter.End()
+			return err
+		}
+		jobs = append(jobs, job)
+	}
+
+	bulkWriter.End()
+	for _, job := range jobs {
+		if _, err := job.Results(); err != nil {
+			slog.ErrorContext(ctx, "Failed to get job results", "error", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// ReplaceStatsDays stores the days and deletes the stored days that are not among them.
+func ReplaceStatsDays(ctx context.Context, days []*objects.StatsDay) error {
+	defer metrics.ObserveBackend("firestore", "ReplaceStatsDays", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return err
+	}
+	defer client.Close()
+
+	collection := client.Collection(StatsDays)
+	stale := map[string]*firestore.DocumentRef{}
+	refs := collection.DocumentRefs(ctx)
+	for {
+		ref, err := refs.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to list documents", "error", err)
+			return err
+		}
+		stale[ref.ID] = ref
+	}
+
+	bulkWriter := client.BulkWriter(ctx)
+	jobs := make([]*firestore.BulkWriterJob, 0, len(days)+len(stale))
+	for _, day := range days {
+		delete(stale, day.Date)
+		job, err := bulkWriter.Set(collection.Doc(day.Date), day)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to create job", "error", err)
+			bulkWriter.End()
+			return err
+		}
+		jobs = append(jobs, job)
+	}
+	for _, ref := range stale {
+		job, err := bulkWriter.Delete(ref)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to create job", "error", err)
+			bulkWriter.End()
+			return err
+		}
+		jobs = append(jobs, job)
+	}
+
+	bulkWriter.End()
+	for _, job := range jobs {
+		if _, err := job.Results(); err != nil {
+			slog.ErrorContext(ctx, "Failed to get job results", "error", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// FetchStatsDays returns the stored days between from and to, inclusive and formatted
+// as 2006-01-02. An empty bound leaves that side open.
+func FetchStatsDays(ctx context.Context, from string, to string) ([]*objects.StatsDay, error) {
+	defer metrics.ObserveBackend("firestore", "FetchStatsDays", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return nil, err
+	}
+	defer client.Close()
+
+	query := client.Collection(StatsDays).OrderBy("Date", firestore.Asc)
+	if from != "" {
+		query = query.Where("Date", ">=", from)
+	}
+	if to != "" {
+		query = query.Where("Date", "<=", to)
+	}
+	docs, err := query.Documents(ctx).GetAll()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to retrieve documents", "error", err)
+		return nil, err
+	}
+
+	days := make([]*objects.StatsDay, 0, len(docs))
+	for _, doc := range docs {
+		var day objects.StatsDay
+		if err := doc.DataTo(&day); err != nil {
+			slog.ErrorContext(ctx, "Failed to read document", "error", err)
+			return nil, err
+		}
+		days = append(days, &day)
+	}
+	return days, nil
+}
@@ -0,0 +1,228 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"refugio/metrics"
+	"refugio/objects"
+	"slices"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrTakedownDecided is returned when deciding a takedown that is no longer pending.
+var ErrTakedownDecided = errors.New("the takedown was already decided")
+
+// AddTakedown stores a new takedown and sets its Id.
+func AddTakedown(ctx context.Context, takedown *objects.Takedown) error {
+	defer metrics.ObserveBackend("firestore", "AddTakedown", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return err
+	}
+	defer client.Close()
+
+	ref := client.Collection(Takedowns).NewDoc()
+	takedown.Id = ref.ID
+	if _, err := ref.Create(ctx, takedown); err != nil {
+		slog.ErrorContext(ctx, "Failed to add takedown", "error", err)
+		return err
+	}
+	return nil
+}
+
+// FetchTakedown returns the takedown, or nil when there is none with the id.
+func FetchTakedown(ctx context.Context, id string) (*objects.Takedown, error) {
+	defer metrics.ObserveBackend("firestore", "FetchTakedown", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return nil, err
+	}
+	defer client.Close()
+
+	doc, err := client.Collection(Takedowns).Doc(id).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to retrieve takedown", "id", id, "error", err)
+		return nil, err
+	}
+	var takedown objects.Takedown
+	if err := doc.DataTo(&takedown); err != nil {
+		slog.ErrorContext(ctx, "Failed to read takedown", "id", id, "error", err)
+		return nil, err
+	}
+	return &takedown, nil
+}
+
+// FetchTakedowns lists the takedowns with the status, or all of them when it is
+// empty, the oldest first.
+func FetchTakedowns(ctx context.Context, takedownStatus string) ([]*objects.Takedown, error) {
+	defer metrics.ObserveBackend("firestore", "FetchTakedowns", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return nil, err
+	}
+	defer client.Close()
+
+	query := client.Collection(Takedowns).Query
+	if takedownStatus != "" {
+		query = query.Where("Status", "==", takedownStatus)
+	}
+	docs, err := query.Documents(ctx).GetAll()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to retrieve takedowns", "error", err)
+		return nil, err
+	}
+	takedowns := make([]*objects.Takedown, 0, len(docs))
+	for _, doc := range docs {
+		var takedown objects.Takedown
+		if err := doc.DataTo(&takedown); err != nil {
+			slog.ErrorContext(ctx, "Failed to read takedown", "id", doc.Ref.ID, "error", err)
+			continue
+		}
+		takedowns = append(takedowns, &takedown)
+	}
+	// Sorted here, ordering the filtered query would need a composite index
+	slices.SortFunc(takedowns, func(a, b *objects.Takedown) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return takedowns, nil
+}
+
+// DecideTakedown approves or rejects a pending takedown. Approving also puts its key
+// on the suppression list, in the same transaction.
+func DecideTakedown(ctx context.Context, id string, decision string, note string) (*objects.Takedown, error) {
+	defer metrics.ObserveBackend("firestore", "DecideTakedown", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return nil, err
+	}
+	defer client.Close()
+
+	ref := client.Collection(Takedowns).Doc(id)
+	var takedown *objects.Takedown
+	err = client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			takedown = nil
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		takedown = &objects.Takedown{}
+		if err := doc.DataTo(takedown); err != nil {
+			return err
+		}
+		if takedown.Status != objects.TakedownPending {
+			return ErrTakedownDecided
+		}
+
+		now := time.Now()
+		takedown.Status = decision
+		takedown.DecidedAt = &now
+		takedown.Events = append(takedown.Events, objects.TakedownEvent{Action: decision, Actor: ActorFrom(ctx), Note: note, Timestamp: now})
+		if err := tx.Set(ref, takedown); err != nil {
+			return err
+		}
+		if decision != objects.TakedownApproved {
+			return nil
+		}
+		return tx.Set(client.Collection(Suppressions).Doc(takedown.Key), &objects.Suppression{
+			Key:        takedown.Key,
+			Kind:       takedown.Kind,
+			Correction: takedown.Correction,
+			TakedownId: takedown.Id,
+			CreatedAt:  now,
+		})
+	})
+	if err != nil && !errors.Is(err, ErrTakedownDecided) {
+		slog.ErrorContext(ctx, "Failed to decide takedown", "id", id, "error", err)
+	}
+	return takedown, err
+}
+
+// FetchSuppressions returns the suppression list by key.
+func FetchSuppressions(ctx context.Context) (map[string]*objects.Suppression, error) {
+	defer metrics.ObserveBackend("firestore", "FetchSuppressions", time.Now())
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return nil, err
+	}
+	defer client.Close()
+
+	docs, err := client.Collection(Suppressions).Documents(ctx).GetAll()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to retrieve suppressions", "error", err)
+		return nil, err
+	}
+	suppressions := make(map[string]*objects.Suppression, len(docs))
+	for _, doc := range docs {
+		var suppression objects.Suppression
+		if err := doc.DataTo(&suppression); err != nil {
+			slog.ErrorContext(ctx, "Failed to read suppression", "key", doc.Ref.ID, "error", err)
+			return nil, err
+		}
+		suppressions[doc.Ref.ID] = &suppression
+	}
+	return suppressions, nil
+}
+
+// ApplyTakedown applies an approved takedown to its record and to the history of the
+// record, which holds every version of it. A remove deletes the record and its history,
+// a redact or a correct changes the free text fields of the record and of every version.
+// A revision holding no data of the record then tells what was done. Applying it again
+// does it all again, for when it failed halfway.
+func ApplyTakedown(ctx context.Context, takedown *objects.Takedown) error {
+	defer metrics.ObserveBackend("firestore", "ApplyTakedown", time.Now())
+	slog.InfoContext(ctx, "Applying takedown in Firestore", "collection", PessoasAbrigos, "takedown", takedown.Id, "kind", takedown.Kind)
+	keys := []string{takedown.Key}
+	change := func(pessoa *objects.PessoaResult) { pessoa.ClearFreeText() }
+	if takedown.Kind == objects.TakedownCorrect {
+		change = func(pessoa *objects.PessoaResult) { pessoa.Correct(takedown.Correction) }
+	}
+	err := updatePessoas(ctx, keys, func(tx *firestore.Transaction, doc *firestore.DocumentSnapshot, stored *objects.PessoaResult, delta *counterDelta) error {
+		if takedown.Kind == objects.TakedownRemove {
+			delta.add(stored, -1)
+			return tx.Delete(doc.Ref)
+		}
+		change(stored)
+		return tx.Update(doc.Ref, []firestore.Update{{Path: "Idade", Value: stored.Idade}, {Path: "Observacao", Value: stored.Observacao}})
+	})
+	if err != nil {
+		return err
+	}
+
+	client, err := createClient(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating client", "error", err)
+		return err
+	}
+	defer client.Close()
+
+	if takedown.Kind == objects.TakedownRemove {
+		err = deleteRevisions(ctx, client, keys)
+	} else {
+		err = rewriteRevisions(ctx, client, keys, change)
+	}
+	if err != nil {
+		return err
+	}
+	revision := &objects.Revision{Action: objects.RevisionTakedown, Actor: ActorFrom(ctx), TakedownId: takedown.Id, Timestamp: time.Now()}
+	if _, err := client.Collection(PessoasAbrigos).Doc(takedown.Key).Collection(Revisions).NewDoc().Create(ctx, revision); err != nil {
+		slog.ErrorContext(ctx, "Failed to add takedown revision", "takedown", takedown.Id, "error", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"refugio/retention"
+	"refugio/scheduler"
+	"refugio/stats"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var retentionCmd = &cobra.Command{
+	Use:   "retention",
+	Short: "List the records no scrape confirmed for a while",
+	Long:  "List the records that reached a stage of the retention policy: stale, hidden from the default search or due to be purged. The days of each stage are read from RETENTION_STALE_DAYS, RETENTION_HIDDEN_DAYS and RETENTION_PURGE_DAYS.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stage, _ := cmd.Flags().GetString("stage")
+		if stage != "" && !slices.Contains(retention.Stages, stage) {
+			return fmt.Errorf("unknown stage %q, use %s", stage, strings.Join(retention.Stages, ", "))
+		}
+		policy, err := retention.CurrentPolicy()
+		if err != nil {
+			return err
+		}
+		report, err := retention.NewReport(cmd.Context(), policy, time.Now())
+		if err != nil {
+			return err
+		}
+		return writeRetentionReport(os.Stdout, report, stage)
+	},
+}
+
+var retentionPurgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Delete the records that reached the purge stage",
+	Long:  "Delete the records no scrape confirmed for RETENTION_PURGE_DAYS days. Run it with --isDryRun first to list them. It holds the scrape lock, and deletes the history of the records with them.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		isDryRun, _ := cmd.Flags().GetBool("isDryRun")
+		policy, err := retention.CurrentPolicy()
+		if err != nil {
+			return err
+		}
+		if policy.PurgeDays == 0 {
+			return fmt.Errorf("purging is off, set RETENTION_PURGE_DAYS")
+		}
+		if isDryRun {
+			report, err := retention.NewReport(cmd.Context(), policy, time.Now())
+			if err != nil {
+				return err
+			}
+			return writeRetentionReport(os.Stdout, report, retention.Purge)
+		}
+
+		return scheduler.WithLock(cliContext(cmd.Context()), func(ctx context.Context) error {
+			report, err := retention.NewReport(ctx, policy, time.Now())
+			if err != nil {
+				return err
+			}
+			purged, err := retention.PurgeExpired(ctx, report)
+			if len(purged) > 0 {
+				// Still under the lock, as after the scrapes
+				if _, err := stats.Refresh(ctx, report.Pessoas(retention.Purge)); err != nil {
+					slog.ErrorContext(ctx, "Error refreshing statistics", "error", err)
+				}
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Purged %d records not confirmed for %d days\n", len(purged), policy.PurgeDays)
+			return nil
+		})
+	},
+}
+
+// writeRetentionReport lists the entries of the report in the stage, or in every
+// stage when it is empty, followed by the totals.
+func writeRetentionReport(w io.Writer, report *retention.Report, stage string) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "KEY\tSTAGE\tLAST CONFIRMED\tSHEET\tNOME\tABRIGO")
+	for _, entry := range report.Entries {
+		if stage != "" && entry.Stage != stage {
+			continue
+		}
+		sheetId := ""
+		if entry.Pessoa.SheetId != nil {
+			sheetId = *entry.Pessoa.SheetId
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", entry.Key, entry.Stage, entry.LastConfirmed.In(stats.Location).Format(time.DateTime), sheetId, entry.Pessoa.Nome, entry.Pessoa.Abrigo)
+	}
+	fmt.Fprintln(tw)
+
+	counts := report.Counts()
+	for _, s := range retention.Stages {
+		days := report.Policy.Days(s)
+		if days == 0 {
+			fmt.Fprintf(tw, "%s\toff\t\n", s)
+			continue
+		}
+		fmt.Fprintf(tw, "%s\tafter %d days\t%d records\n", s, days, counts[s])
+	}
+	return tw.Flush()
+}
+
+func init() {
+	retentionCmd.Flags().String("stage", "", "Only the records in this stage: "+strings.Join(retention.Stages, ", "))
+	retentionPurgeCmd.Flags().Bool("isDryRun", false, "List the records that would be purged without deleting them")
+	retentionCmd.AddCommand(retentionPurgeCmd)
+}
@@ -0,0 +1,217 @@
+// Package retention expires the records no scrape has confirmed for a while, as
+// their source spreadsheets were deleted or stopped listing them. A record goes
+// through three stages as the days pass since it was last confirmed: it is flagged
+// stale, then hidden from the default search and finally purged.
+package retention
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"refugio/objects"
+	"refugio/repository"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/* Stages */
+const (
+	Stale  = "stale"
+	Hidden = "hidden"
+	Purge  = "purge"
+)
+
+var Stages = []string{Stale, Hidden, Purge}
+
+// How many records are read from Firestore at a time, as in the export
+const pageSize = 500
+
+// Policy has how many days after it was last confirmed a record reaches each stage,
+// zero turning the stage off.
+type Policy struct {
+	StaleDays  int
+	HiddenDays int
+	PurgeDays  int
+}
+
+// DefaultPolicy flags a record after two weeks and hides it after a month. Purging is
+// off until it is configured.
+var DefaultPolicy = Policy{StaleDays: 14, HiddenDays: 30}
+
+// CurrentPolicy reads the days from RETENTION_STALE_DAYS, RETENTION_HIDDEN_DAYS and
+// RETENTION_PURGE_DAYS, falling back to DefaultPolicy for the ones not set.
+func CurrentPolicy() (Policy, error) {
+	policy := DefaultPolicy
+	for _, stage := range Stages {
+		name := "RETENTION_" + strings.ToUpper(stage) + "_DAYS"
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return policy, fmt.Errorf("%s must be a number of days, zero turning the stage off: %q", name, value)
+		}
+		switch stage {
+		case Stale:
+			policy.StaleDays = n
+		case Hidden:
+			policy.HiddenDays = n
+		case Purge:
+			policy.PurgeDays = n
+		}
+	}
+	return policy, policy.Validate()
+}
+
+// Validate checks that the stages that are on start in order, a record is never
+// hidden before it is flagged stale nor purged before it is hidden.
+func (p Policy) Validate() error {
+	previous := ""
+	for _, stage := range Stages {
+		days := p.Days(stage)
+		if days == 0 {
+			continue
+		}
+		if previous != "" && days < p.Days(previous) {
+			return fmt.Errorf("the %s stage starts after %d days, before the %s stage at %d days", stage, days, previous, p.Days(previous))
+		}
+		previous = stage
+	}
+	return nil
+}
+
+// Stage returns the last stage the record reached, empty when it reached none.
+func (p Policy) Stage(pessoa *objects.PessoaResult, now time.Time) string {
+	confirmed := pessoa.LastConfirmed()
+	if confirmed.IsZero() {
+		return ""
+	}
+	stage := ""
+	for _, s := range Stages {
+		if days := p.Days(s); days > 0 && confirmed.Before(now.AddDate(0, 0, -days)) {
+			stage = s
+		}
+	}
+	return stage
+}
+
+// IsStale tells whether the record reached any stage.
+func (p Policy) IsStale(pessoa *objects.PessoaResult, now time.Time) bool {
+	return p.Stage(pessoa, now) != ""
+}
+
+// IsHidden tells whether the record is left out of the default search.
+func (p Policy) IsHidden(pessoa *objects.PessoaResult, now time.Time) bool {
+	stage := p.Stage(pessoa, now)
+	return stage == Hidden || stage == Purge
+}
+
+// Days is when the stage starts, zero when it is off.
+func (p Policy) Days(stage string) int {
+	switch stage {
+	case Stale:
+		return p.StaleDays
+	case Hidden:
+		return p.HiddenDays
+	case Purge:
+		return p.PurgeDays
+	}
+	return 0
+}
+
+// firstDays is when the first stage that is on starts.
+func (p Policy) firstDays() int {
+	first := 0
+	for _, s := range Stages {
+		if days := p.Days(s); days > 0 && (first == 0 || days < first) {
+			first = days
+		}
+	}
+	return first
+}
+
+// Entry is a record that reached a stage.
+type Entry struct {
+	Key           string
+	Stage         string
+	LastConfirmed time.Time
+	Pessoa        *objects.PessoaResult
+}
+
+// Report lists the records that reached a stage, the ones confirmed the longest ago first.
+type Report struct {
+	Policy      Policy
+	GeneratedAt time.Time
+	Entries     []*Entry
+}
+
+// Counts totals the entries by stage.
+func (r *Report) Counts() map[string]int {
+	counts := map[string]int{}
+	for _, entry := range r.Entries {
+		counts[entry.Stage]++
+	}
+	return counts
+}
+
+// Keys lists the keys of the entries in the given stage.
+func (r *Report) Keys(stage string) []string {
+	var keys []string
+	for _, entry := range r.Entries {
+		if entry.Stage == stage {
+			keys = append(keys, entry.Key)
+		}
+	}
+	return keys
+}
+
+// Pessoas lists the records of the entries in the given stage.
+func (r *Report) Pessoas(stage string) []*objects.PessoaResult {
+	var pessoas []*objects.PessoaResult
+	for _, entry := range r.Entries {
+		if entry.Stage == stage {
+			pessoas = append(pessoas, entry.Pessoa)
+		}
+	}
+	return pessoas
+}
+
+// NewReport reads the stored records a page at a time and lists the ones that reached
+// a stage. A record is never confirmed before it was written, so only the ones written
+// before the first stage are read.
+func NewReport(ctx context.Context, policy Policy, now time.Time) (*Report, error) {
+	report := &Report{Policy: policy, GeneratedAt: now}
+	first := policy.firstDays()
+	if first == 0 {
+		return report, nil
+	}
+	filter := objects.PessoaFilter{To: now.AddDate(0, 0, -first)}
+	err := repository.ForEachPessoaPage(ctx, filter, pageSize, func(pessoas []*objects.PessoaResult) error {
+		for _, pessoa := range pessoas {
+			if stage := policy.Stage(pessoa, now); stage != "" {
+				report.Entries = append(report.Entries, &Entry{Key: pessoa.AggregateKey(), Stage: stage, LastConfirmed: pessoa.LastConfirmed(), Pessoa: pessoa})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(report.Entries, func(a, b *Entry) int {
+		return a.LastConfirmed.Compare(b.LastConfirmed)
+	})
+	return report, nil
+}
+
+// PurgeExpired deletes the records of the report that reached the purge stage, along
+// with their history, and returns their keys.
+func PurgeExpired(ctx context.Context, report *Report) ([]string, error) {
+	keys := report.Keys(Purge)
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	return keys, repository.PurgePessoasFromFirestore(ctx, keys)
+}
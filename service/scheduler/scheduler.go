@@ -0,0 +1,326 @@
+package scheduler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	mathrand "math/rand"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"refugio/logging"
+	"refugio/metrics"
+	"refugio/objects"
+	"refugio/repository"
+	"refugio/sheetscraper"
+	"refugio/stats"
+	"refugio/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const (
+	ScrapeLock = "scrape"
+	// LockTTL bounds how long a crashed scrape keeps others from running
+	LockTTL = time.Hour
+	// LockRenewEvery is how often a running scrape extends its lock by another LockTTL
+	LockRenewEvery = LockTTL / 4
+)
+
+var (
+	ErrLocked   = errors.New("another scrape is already running")
+	ErrLockLost = errors.New("the scrape lock was taken by another instance")
+)
+
+var (
+	owner  string
+	mu     sync.Mutex
+	active *Scheduler
+)
+
+func init() {
+	hostname, _ := os.Hostname()
+	owner = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
+
+// RunOnce scrapes the selected sources while holding the scrape lock and stores the
+// outcome as the last run. Dry runs neither take the lock nor store anything.
+func RunOnce(ctx context.Context, opts sheetscraper.ScrapeOptions) (*objects.ScrapeRun, *sheetscraper.Plan, error) {
+	run := &objects.ScrapeRun{
+		Id:        newRunId(),
+		Owner:     owner,
+		IsDryRun:  opts.IsDryRun,
+		StartedAt: time.Now(),
+		SheetIds:  sheetscraper.SelectedSheetIds(opts.Selector),
+	}
+	ctx = logging.With(ctx, "scrape_run", run.Id)
+	ctx = repository.WithActor(ctx, objects.Actor{Kind: objects.ActorScrape, Id: run.Id})
+	ctx, span := tracing.Start(ctx, "scrape.Run", attribute.String("scrape.run_id", run.Id), attribute.Bool("scrape.dry_run", opts.IsDryRun))
+	defer span.End()
+	// The lock and the run are stored even when ctx is cancelled halfway
+	storeCtx := context.WithoutCancel(ctx)
+
+	if !opts.IsDryRun {
+		lockCtx, release, err := holdLock(ctx)
+		if err != nil {
+			return run, nil, err
+		}
+		defer release()
+		ctx = lockCtx
+	}
+
+	plan, err := sheetscraper.Scrape(ctx, opts)
+	// The ranges left after the lock was lost were skipped
+	if cause := context.Cause(ctx); err == nil && errors.Is(cause, ErrLockLost) {
+		err = cause
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	run.FinishedAt = time.Now()
+	run.Success = err == nil
+	if err != nil {
+		run.Error = err.Error()
+	}
+	if plan != nil {
+		run.Counts = plan.Counts()
+	}
+	metrics.ScrapeLastRun.WithLabelValues(strconv.FormatBool(run.Success)).Set(float64(run.FinishedAt.Unix()))
+	metrics.ScrapeDuration.Set(run.FinishedAt.Sub(run.StartedAt).Seconds())
+
+	if !opts.IsDryRun {
+		repository.AddScrapeRunToFirestore(storeCtx, run)
+		if run.Success {
+			repository.UpdateSourceScrapeTimes(storeCtx, run.SheetIds, run.FinishedAt)
+		}
+		// Still under the lock, so no other scrape changes the records while they are counted
+		if plan != nil && plan.HasChanges() {
+			if _, err := stats.Refresh(storeCtx, plan.Changed()); err != nil {
+				slog.ErrorContext(ctx, "Error refreshing statistics", "error", err)
+			}
+		}
+	}
+	return run, plan, err
+}
+
+// WithLock runs fn while holding the scrape lock, for maintenance that must not
+// race a scrape.
+func WithLock(ctx context.Context, fn func(ctx context.Context) error) error {
+	lockCtx, release, err := holdLock(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	if err := fn(lockCtx); err != nil {
+		if cause := context.Cause(lockCtx); errors.Is(cause, ErrLockLost) {
+			return fmt.Errorf("%w: %w", cause, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// holdLock takes the scrape lock and renews it every LockRenewEvery until release is
+// called, so that work running past LockTTL keeps it. The returned context is cancelled
+// with ErrLockLost when another instance took the lock, stopping the work before the
+// two of them race. A renewal that fails to reach Firestore is retried on the next one.
+func holdLock(ctx context.Context) (context.Context, func(), error) {
+	acquired, err := repository.AcquireLock(ctx, ScrapeLock, owner, LockTTL)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !acquired {
+		return nil, nil, ErrLocked
+	}
+
+	lockCtx, cancel := context.WithCancelCause(ctx)
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(LockRenewEvery)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-lockCtx.Done():
+				return
+			case <-ticker.C:
+			}
+			acquired, err := repository.AcquireLock(lockCtx, ScrapeLock, owner, LockTTL)
+			if err != nil {
+				slog.WarnContext(ctx, "Error renewing the scrape lock", "error", err)
+				continue
+			}
+			if !acquired {
+				slog.ErrorContext(ctx, "Scrape lock lost, stopping", "lock", ScrapeLock)
+				cancel(ErrLockLost)
+				return
+			}
+		}
+	}()
+
+	release := func() {
+		close(done)
+		<-stopped
+		cancel(nil)
+		repository.ReleaseLock(context.WithoutCancel(ctx), ScrapeLock, owner)
+	}
+	return lockCtx, release, nil
+}
+
+// Scheduler scrapes every source once per Interval.
+type Scheduler struct {
+	Interval time.Duration
+	// Jitter is the maximum random delay added to every wait
+	Jitter  time.Duration
+	Options sheetscraper.ScrapeOptions
+	// OnRun is called after every run that reached the scraper
+	OnRun func(run *objects.ScrapeRun, plan *sheetscraper.Plan)
+
+	mu           sync.Mutex
+	running      bool
+	runningSince time.Time
+	lastRun      *objects.ScrapeRun
+	lastErr      error
+	nextRuns     map[string]time.Time
+}
+
+type Status struct {
+	Interval string `json:"interval"`
+	Running  bool   `json:"running"`
+	// RunningSince is when the current run started
+	RunningSince *time.Time           `json:"running_since,omitempty"`
+	LastRun      *objects.ScrapeRun   `json:"last_run"`
+	LastErr      string               `json:"last_error,omitempty"`
+	NextRuns     map[string]time.Time `json:"next_runs"`
+}
+
+// Active returns the scheduler running in this process, if any.
+func Active() *Scheduler {
+	mu.Lock()
+	defer mu.Unlock()
+	return active
+}
+
+// Run blocks, scraping whatever is due, until ctx is done.
+func (s *Scheduler) Run(ctx context.Context) {
+	mu.Lock()
+	active = s
+	mu.Unlock()
+
+	s.mu.Lock()
+	s.nextRuns = map[string]time.Time{}
+	s.mu.Unlock()
+
+	slog.InfoContext(ctx, "Scheduler started", "interval", s.Interval.String())
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.jitter()):
+		}
+		s.runDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.Interval):
+		}
+	}
+}
+
+func (s *Scheduler) Status() Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := Status{
+		Interval: s.Interval.String(),
+		Running:  s.running,
+		LastRun:  s.lastRun,
+		NextRuns: make(map[string]time.Time, len(s.nextRuns)),
+	}
+	if s.running {
+		runningSince := s.runningSince
+		status.RunningSince = &runningSince
+	}
+	if s.lastErr != nil {
+		status.LastErr = s.lastErr.Error()
+	}
+	for id, next := range s.nextRuns {
+		status.NextRuns[id] = next
+	}
+	return status
+}
+
+// runDue scrapes the sources not scraped for an Interval. Scrape times come from the
+// repository so that several instances share a single schedule.
+func (s *Scheduler) runDue(ctx context.Context) {
+	scrapeTimes, err := repository.FetchSourceScrapeTimes(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "Error fetching source scrape times, using local schedule", "error", err)
+	}
+
+	now := time.Now()
+	var due []string
+	s.mu.Lock()
+	for id, scrapedAt := range scrapeTimes {
+		s.nextRuns[id] = scrapedAt.Add(s.Interval)
+	}
+	for _, id := range sheetscraper.SelectedSheetIds(s.Options.Selector) {
+		if next, ok := s.nextRuns[id]; !ok || !next.After(now) {
+			due = append(due, id)
+		}
+	}
+	if len(due) == 0 {
+		s.mu.Unlock()
+		return
+	}
+	s.running = true
+	s.runningSince = now
+	s.mu.Unlock()
+
+	opts := s.Options
+	opts.Selector.SourceIds = due
+	run, plan, err := RunOnce(ctx, opts)
+	if err != nil {
+		slog.ErrorContext(ctx, "Scheduled scrape failed", "scrape_run", run.Id, "error", err)
+	}
+
+	s.mu.Lock()
+	s.running = false
+	s.lastErr = err
+	// A locked run did not happen, so the sources stay due
+	if !errors.Is(err, ErrLocked) {
+		s.lastRun = run
+		for _, id := range due {
+			s.nextRuns[id] = now.Add(s.Interval)
+		}
+	}
+	s.mu.Unlock()
+
+	if s.OnRun != nil && !errors.Is(err, ErrLocked) {
+		s.OnRun(run, plan)
+	}
+}
+
+func (s *Scheduler) jitter() time.Duration {
+	if s.Jitter <= 0 {
+		return 0
+	}
+	return time.Duration(mathrand.Int63n(int64(s.Jitter)))
+}
+
+func newRunId() string {
+	suffix := make([]byte, 4)
+	rand.Read(suffix)
+	return time.Now().UTC().Format("20060102T150405Z") + "-" + hex.EncodeToString(suffix)
+}
@@ -1,6 +1,7 @@
 package sheetscraper
 
 import (
+	"context"
 	"strings"
 )
 
@@ -9,15 +10,19 @@ const (
 	AbrigoDeduplicationRange   = "Sheet3"
 )
 
-func getAbrigosMapping() map[string]string {
+func getAbrigosMapping(ctx context.Context) map[string]string {
 	ss := SheetsSource{}
-	content, _, err := ss.Read(AbrigoDeduplicationSheetId, AbrigoDeduplicationRange)
+	content, _, err := ss.Read(ctx, AbrigoDeduplicationSheetId, AbrigoDeduplicationRange)
 	if err != nil {
 		panic(err)
 	}
+	return abrigosMappingFromContent(content.([][]interface{}))
+}
+
+func abrigosMappingFromContent(content [][]interface{}) map[string]string {
 	abrigoDeduplicationMap := make(map[string]string)
 
-	for i, row := range content.([][]interface{}) {
+	for i, row := range content {
 		if i == 0 || len(row) < 2 {
 			continue
 		}
@@ -1,9 +1,70 @@
 package sheetscraper
 
+import (
+	"slices"
+
+	"refugio/objects"
+)
+
 type SheetConfig struct {
 	id          string
 	sheetRanges []string
 	name        string
+	// city where the people of the spreadsheet are sheltered, empty when it is not known
+	city string
+	// region of the city, when it is not in cityRegions
+	region string
+	// organization keeps the spreadsheet, and contact is how to reach them. Both are
+	// shown with the source, empty when they are not known.
+	organization string
+	contact      string
+}
+
+// cityRegions are the regions of the cities of the spreadsheets.
+var cityRegions = map[string]string{
+	"Cachoeirinha":        "Região Metropolitana de Porto Alegre",
+	"Canoas":              "Região Metropolitana de Porto Alegre",
+	"Eldorado do Sul":     "Região Metropolitana de Porto Alegre",
+	"Gravataí":            "Região Metropolitana de Porto Alegre",
+	"Novo Hamburgo":       "Região Metropolitana de Porto Alegre",
+	"Porto Alegre":        "Região Metropolitana de Porto Alegre",
+	"Portão":              "Região Metropolitana de Porto Alegre",
+	"São Leopoldo":        "Região Metropolitana de Porto Alegre",
+	"Cruzeiro do Sul":     "Vale do Taquari",
+	"Estrela":             "Vale do Taquari",
+	"Lajeado":             "Vale do Taquari",
+	"Venâncio Aires":      "Vale do Rio Pardo",
+	"Cerro Grande do Sul": "Centro-Sul",
+	"Sentinela do Sul":    "Centro-Sul",
+}
+
+// source is the configured spreadsheet as it is stored in the sources collection.
+func (cfg SheetConfig) source() *objects.Source {
+	region := cfg.region
+	if region == "" {
+		region = cityRegions[cfg.city]
+	}
+	return &objects.Source{
+		Nome:        cfg.name,
+		SheetId:     cfg.id,
+		URL:         "https://docs.google.com/spreadsheets/d/" + cfg.id,
+		Configured:  true,
+		Organizacao: cfg.organization,
+		Contato:     cfg.contact,
+		Cidade:      cfg.city,
+		Regiao:      region,
+	}
+}
+
+// SelectedSheetIds lists the configured spreadsheet ids matched by the selector.
+func SelectedSheetIds(selector Selector) []string {
+	var ids []string
+	for _, cfg := range Config {
+		if selector.MatchSource(cfg) && !slices.Contains(ids, cfg.id) {
+			ids = append(ids, cfg.id)
+		}
+	}
+	return ids
 }
 
 var Config []SheetConfig = []SheetConfig{
@@ -19,6 +80,7 @@ var Config []SheetConfig = []SheetConfig{
 			"Queila!A1:ZZ",
 			"Lista dos Acolhidos em Gravataí "},
 		name: "GRAVATAÍ - Lista de acolhidos por abrigo",
+		city: "Gravataí",
 	},
 	{
 		id:          "14WIowAKQo5o_FviBw_6hRxnzAclw5xTvHbUiQuU8qDw",
@@ -39,6 +101,7 @@ var Config []SheetConfig = []SheetConfig{
 		id:          "1zt_yrzvU2nmihyZG7rR67iqlkwlcDz3LjSb1UBynQ3c",
 		sheetRanges: []string{"ALOJADOS x ABRIGOS!A1:ZZ"},
 		name:        "SAO LEOPOLDO - LISTA ALOJADOS",
+		city:        "São Leopoldo",
 	},
 	{
 		id:          "1frgtJ9eK05OqsyLwOBiZ2Q6E7e4_pWyrb7fJioqfEMs",
@@ -81,6 +144,7 @@ var Config []SheetConfig = []SheetConfig{
 			"PARÓQUIA SAO LUIS!A1:ZZ",
 		},
 		name: "ABRIGADOS EM CANOAS 01",
+		city: "Canoas",
 	},
 	{
 		id:          "1Gf78W5yY0Yiljg-E0rYqbRjxYmBPcG2BtfpGwFk-K5M",
@@ -91,6 +155,7 @@ var Config []SheetConfig = []SheetConfig{
 		id:          "1T_yd-M6BG1qYdQKeMo2U_AffqRCxkExqpB39iQXig5s",
 		sheetRanges: []string{"ENCONTRADOS!A1:ZZ"},
 		name:        "ULBRA - CANOAS PRÉDIO 11",
+		city:        "Canoas",
 	},
 	{
 		id: "1RGRoIzSFQaaJF1xZsJhQsMJxXnXWzfZfas29T_PefmY",
@@ -110,11 +175,13 @@ var Config []SheetConfig = []SheetConfig{
 			"IGREJA NOSSA SENHORA DAS GRAÇAS !A1:ZZ",
 		},
 		name: "ACOLHIDOS NOS ALOJAMENTOS DA PMNH",
+		city: "Novo Hamburgo",
 	},
 	{
 		id:          "1hKJVs-RLiSUpx-1Rd9wS1k8RLqxkPWK4hNob-t8v2Ko",
 		sheetRanges: []string{"NOME/ABRIGO!A1:ZZ"},
 		name:        "LISTA RESGATADOS ELDORADO - 04/05",
+		city:        "Eldorado do Sul",
 	},
 	{id: "1-xhmS1VQ95LFI05XG8o9JO3mPk8KxQtrxAZe4GNYO3I",
 		sheetRanges: []string{
@@ -134,16 +201,19 @@ var Config []SheetConfig = []SheetConfig{
 		id:          "1bNw-t0RUE-AP-2quCU80w5meGYVtD7WjjuESb7tXxTo",
 		sheetRanges: []string{"Abrigados Lajeado!A1:ZZ"},
 		name:        "Abrigados Lajeado",
+		city:        "Lajeado",
 	},
 	{
 		id:          "1O4NqkxHvFDoziS_zClwIjGIAVAGbYkfHTRrM6ogySTo",
 		sheetRanges: []string{"Página1!A1:ZZ"},
 		name:        "Abrigados - Venâncio Aires",
+		city:        "Venâncio Aires",
 	},
 	{
 		id:          "1Pd8NVuEtnR7-IlLF7cJ3XY7yVSoJMgY47-eepe2BBXo",
 		sheetRanges: []string{"Resgatados!A1:ZZ"},
 		name:        "RESGATADOS - Cruzeiro do Sul",
+		city:        "Cruzeiro do Sul",
 	},
 	{
 		id:          "1AaQLs2Dqc6lrYstyF8UGLrihCzRRLsy8rlIRixJQ7VU",
@@ -169,16 +239,19 @@ var Config []SheetConfig = []SheetConfig{
 		id:          "1wvtgK7ZO9KuJsFDI9syyPWmEyqYoKw2PKssmgfo_jCU",
 		sheetRanges: []string{"Form Responses 1!A1:ZZ"},
 		name:        "localizacao desabrigados canoas (Responses)-elaborada por voluntarios da prefeitura de Canoas",
+		city:        "Canoas",
 	},
 	{
 		id:          "1fH7OA5bnY5OLfY7Xis6bVQq12VIhS_VIyYYekPBr5NA",
 		sheetRanges: []string{"Respostas ao formulário 1!A1:ZZ"},
 		name:        "Lista Abrigados em Cerro Grande do Sul",
+		city:        "Cerro Grande do Sul",
 	},
 	{
 		id:          "1T_yd-M6BG1qYdQKeMo2U_AffqRCxkExqpB39iQXig5s",
 		sheetRanges: []string{"ENCONTRADOS!A1:ZZ"},
 		name:        "ULBRA - CANOAS PRÉDIO 11",
+		city:        "Canoas",
 	},
 	{
 		id: "1eC6z6RPNNarLMSqVqU-FQOHopCKWCN4CFDn34uTYGcA",
@@ -190,16 +263,19 @@ var Config []SheetConfig = []SheetConfig{
 			"Página 5!A1:ZZ",
 		},
 		name: "PESSOAS ALOJADAS EM SENTINELA DO SUL",
+		city: "Sentinela do Sul",
 	},
 	{
 		id:          "1LdM2ZvYBNdtKekLgHPRs6lg9VGpD-7wBSZsE5c5Mptk",
 		sheetRanges: []string{"Página1!A1:ZZ"},
 		name:        "Abrigados Estrela",
+		city:        "Estrela",
 	},
 	{
 		id:          "1-cA0MB_1aQTOtXVL2pyPWSXjuTMg6U1PsyBAICjdGxo",
 		sheetRanges: []string{"Gravataí!A1:ZZ"},
 		name:        "GRAVATAÍ PESSOAS RESGATADAS",
+		city:        "Gravataí",
 	},
 	{
 		id:          "16rN5pniNiIsbJAv25A0AfW5SdccJjPVDov7EDqwDOQM",
@@ -263,6 +339,7 @@ var Config []SheetConfig = []SheetConfig{
 			"Igreja Betel!A1:ZZ",
 		},
 		name: "Abrigos - Cachoeirinha/RS",
+		city: "Cachoeirinha",
 	},
 	{
 		id:          "1TVv1WEjrPBpnKsFIV60jz0kWPK6idovmnJDaGg6KKXw",
@@ -296,6 +373,7 @@ var Config []SheetConfig = []SheetConfig{
 			"Vila Rica!A1:ZZ",
 		},
 		name: "Abrigos Portão - RS",
+		city: "Portão",
 	},
 	{
 		id: "1q3Z2iX_vop9EumvB-4UyZsVQl58ZQ0M1JnwQsc6HAAo",
@@ -305,6 +383,7 @@ var Config []SheetConfig = []SheetConfig{
 			"08/05!A1:ZZ",
 		},
 		name: "[atualizada 08/05/2024 às 16h25] RESGATADOS - Bairro Humaitá Porto Alegre",
+		city: "Porto Alegre",
 	},
 	{
 		id:          "17GlFds1C-sdRdpWkZczzisTdItbdWgVAMXwXV60htyA",
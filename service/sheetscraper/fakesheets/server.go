@@ -0,0 +1,184 @@
+package fakesheets
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"refugio/sheetscraper"
+
+	"github.com/gorilla/mux"
+)
+
+// Server answers the subset of the Google Sheets API v4 used by SheetsSource.Read, and
+// of the Drive API v3 used by SheetsSource.ModifiedTime, with the fixtures recorded by
+// `app fixtures record`. Fixtures are read from disk on every
+// request, so they can be edited while the server runs.
+type Server struct {
+	dir string
+}
+
+type apiError struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+	} `json:"error"`
+}
+
+type sheetProperties struct {
+	Title string `json:"title"`
+	Index int    `json:"index"`
+}
+
+type sheet struct {
+	Properties sheetProperties `json:"properties"`
+}
+
+type spreadsheet struct {
+	SpreadsheetId string  `json:"spreadsheetId"`
+	Sheets        []sheet `json:"sheets"`
+}
+
+type file struct {
+	Id           string `json:"id"`
+	ModifiedTime string `json:"modifiedTime,omitempty"`
+}
+
+type valueRange struct {
+	Range          string          `json:"range"`
+	MajorDimension string          `json:"majorDimension"`
+	Values         [][]interface{} `json:"values"`
+}
+
+func NewServer(dir string) *Server {
+	return &Server{dir: dir}
+}
+
+func (s *Server) Handler() http.Handler {
+	router := mux.NewRouter().UseEncodedPath()
+	router.HandleFunc("/v4/spreadsheets/{spreadsheetId}", s.getSpreadsheet).Methods(http.MethodGet)
+	router.HandleFunc("/v4/spreadsheets/{spreadsheetId}/values/{range}", s.getValues).Methods(http.MethodGet)
+	router.HandleFunc("/drive/v3/files/{spreadsheetId}", s.getFile).Methods(http.MethodGet)
+	return router
+}
+
+func (s *Server) getSpreadsheet(w http.ResponseWriter, r *http.Request) {
+	sheetId, fixtures, ok := s.fixturesFor(w, r)
+	if !ok {
+		return
+	}
+
+	result := spreadsheet{SpreadsheetId: sheetId}
+	seen := map[string]bool{}
+	for _, fixture := range fixtures {
+		for _, tab := range fixture.Tabs {
+			if !seen[tab] {
+				seen[tab] = true
+				result.Sheets = append(result.Sheets, sheet{Properties: sheetProperties{Title: tab, Index: len(result.Sheets)}})
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) getValues(w http.ResponseWriter, r *http.Request) {
+	_, fixtures, ok := s.fixturesFor(w, r)
+	if !ok {
+		return
+	}
+	sheetRange, err := url.PathUnescape(mux.Vars(r)["range"])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ARGUMENT", err.Error())
+		return
+	}
+
+	fixture := matchRange(fixtures, sheetRange)
+	if fixture == nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ARGUMENT", fmt.Sprintf("Unable to parse range: %s", sheetRange))
+		return
+	}
+	writeJSON(w, http.StatusOK, valueRange{
+		Range:          sheetRange,
+		MajorDimension: "ROWS",
+		Values:         fixture.Values,
+	})
+}
+
+// getFile answers with the latest modified time recorded among the fixtures of the sheet.
+func (s *Server) getFile(w http.ResponseWriter, r *http.Request) {
+	sheetId, fixtures, ok := s.fixturesFor(w, r)
+	if !ok {
+		return
+	}
+
+	var modifiedAt time.Time
+	for _, fixture := range fixtures {
+		if fixture.ModifiedAt != nil && fixture.ModifiedAt.After(modifiedAt) {
+			modifiedAt = *fixture.ModifiedAt
+		}
+	}
+	result := file{Id: sheetId}
+	if !modifiedAt.IsZero() {
+		result.ModifiedTime = modifiedAt.UTC().Format(time.RFC3339Nano)
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) fixturesFor(w http.ResponseWriter, r *http.Request) (string, []*sheetscraper.Fixture, bool) {
+	sheetId, err := url.PathUnescape(mux.Vars(r)["spreadsheetId"])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ARGUMENT", err.Error())
+		return "", nil, false
+	}
+
+	all, err := sheetscraper.LoadFixtures(s.dir)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "INTERNAL", err.Error())
+		return "", nil, false
+	}
+
+	var fixtures []*sheetscraper.Fixture
+	for _, fixture := range all {
+		if fixture.SheetId == sheetId {
+			fixtures = append(fixtures, fixture)
+		}
+	}
+	if len(fixtures) == 0 {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Requested entity was not found.")
+		return "", nil, false
+	}
+	return sheetId, fixtures, true
+}
+
+// matchRange looks for the fixture recorded with the exact same range, falling back to
+// one recorded for the same tab with a different cell range.
+func matchRange(fixtures []*sheetscraper.Fixture, sheetRange string) *sheetscraper.Fixture {
+	for _, fixture := range fixtures {
+		if fixture.Range == sheetRange {
+			return fixture
+		}
+	}
+	for _, fixture := range fixtures {
+		if sheetscraper.TabName(fixture.Range) == sheetscraper.TabName(sheetRange) {
+			return fixture
+		}
+	}
+	return nil
+}
+
+func writeError(w http.ResponseWriter, code int, status string, message string) {
+	var result apiError
+	result.Error.Code = code
+	result.Error.Status = status
+	result.Error.Message = message
+	writeJSON(w, code, result)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
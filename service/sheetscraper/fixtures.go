@@ -0,0 +1,237 @@
+package sheetscraper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"refugio/objects"
+)
+
+const (
+	DefaultFixturesDir = "sheetscraper/testdata/fixtures"
+	DefaultGoldenDir   = "sheetscraper/testdata/golden"
+)
+
+// Fixture is a recorded snapshot of a sheet range, exactly as SheetsSource.Read returned it.
+type Fixture struct {
+	SheetId string          `json:"sheetId"`
+	Range   string          `json:"range"`
+	Tabs    []string        `json:"tabs"`
+	Values  [][]interface{} `json:"values"`
+	// ModifiedAt is when the spreadsheet was last edited, if Drive told it
+	ModifiedAt *time.Time `json:"modifiedAt,omitempty"`
+}
+
+// GoldenRow is the part of a PessoaResult that is compared against golden files.
+// Timestamps are left out on purpose, they change on every run.
+type GoldenRow struct {
+	Nome       string `json:"nome"`
+	Abrigo     string `json:"abrigo"`
+	Idade      string `json:"idade"`
+	Observacao string `json:"observacao"`
+	SheetId    string `json:"sheetId"`
+	URL        string `json:"url"`
+}
+
+var fixtureFileNameRegex = regexp.MustCompile(`[^\p{L}\p{N}_-]+`)
+
+func FixtureFileName(sheetId string, sheetRange string) string {
+	return fixtureFileNameRegex.ReplaceAllString(sheetId+"_"+sheetRange, "_") + ".json"
+}
+
+func RecordFixture(ctx context.Context, sheetId string, sheetRange string, dir string) (string, error) {
+	ss := SheetsSource{}
+	content, tabs, err := ss.Read(ctx, sheetId, sheetRange)
+	if err != nil {
+		return "", err
+	}
+
+	fixture := Fixture{
+		SheetId: sheetId,
+		Range:   sheetRange,
+		Values:  content.([][]interface{}),
+	}
+	for _, tab := range tabs {
+		fixture.Tabs = append(fixture.Tabs, tab.Properties.Title)
+	}
+	// The fixture is still worth recording when the Drive API cannot be reached
+	if modifiedAt, err := ss.ModifiedTime(ctx, sheetId); err == nil {
+		fixture.ModifiedAt = modifiedAt
+	}
+
+	path := filepath.Join(dir, FixtureFileName(sheetId, sheetRange))
+	if err := writeJSONFile(path, fixture); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+func LoadFixtures(dir string) ([]*Fixture, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	fixtures := make([]*Fixture, 0, len(paths))
+	for _, path := range paths {
+		fileContent, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var fixture Fixture
+		if err := json.Unmarshal(fileContent, &fixture); err != nil {
+			return nil, fmt.Errorf("error decoding fixture %s: %w", path, err)
+		}
+		fixtures = append(fixtures, &fixture)
+	}
+	return fixtures, nil
+}
+
+// RunFixture feeds a fixture through the same parsing and cleaning path used by Scrape.
+// Malformed rows fail the fixture.
+func RunFixture(fixture *Fixture, abrigoMap map[string]string) ([]GoldenRow, error) {
+	cfg := SheetConfig{id: fixture.SheetId}
+	for _, c := range Config {
+		if c.id == fixture.SheetId {
+			cfg = c
+			break
+		}
+	}
+
+	data, _, err := parseSheet(cfg, fixture.Range, fixture.Values)
+	if err != nil {
+		return nil, fmt.Errorf("parsing sheetId %s, range %s: %w", fixture.SheetId, fixture.Range, err)
+	}
+	rows := make([]GoldenRow, 0, len(data))
+	for _, pessoa := range cleanPessoas(data, abrigoMap) {
+		rows = append(rows, goldenRowFromPessoa(pessoa))
+	}
+	return rows, nil
+}
+
+// VerifyGoldens runs every fixture and returns one message per fixture whose result
+// differs from its golden file.
+func VerifyGoldens(fixturesDir string, goldenDir string) ([]string, error) {
+	fixtures, abrigoMap, err := loadFixturesAndAbrigos(fixturesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var mismatches []string
+	for _, fixture := range fixtures {
+		name := FixtureFileName(fixture.SheetId, fixture.Range)
+		rows, err := RunFixture(fixture, abrigoMap)
+		if err != nil {
+			mismatches = append(mismatches, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		got, err := json.MarshalIndent(rows, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		want, err := os.ReadFile(filepath.Join(goldenDir, name))
+		if err != nil {
+			mismatches = append(mismatches, fmt.Sprintf("%s: missing golden file: %v", name, err))
+			continue
+		}
+		if diff := firstDifference(want, got); diff != "" {
+			mismatches = append(mismatches, fmt.Sprintf("%s: %s", name, diff))
+		}
+	}
+	return mismatches, nil
+}
+
+// UpdateGoldens overwrites the golden files with the current parsing results.
+func UpdateGoldens(fixturesDir string, goldenDir string) ([]string, error) {
+	fixtures, abrigoMap, err := loadFixturesAndAbrigos(fixturesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var written []string
+	for _, fixture := range fixtures {
+		rows, err := RunFixture(fixture, abrigoMap)
+		if err != nil {
+			return written, err
+		}
+		path := filepath.Join(goldenDir, FixtureFileName(fixture.SheetId, fixture.Range))
+		if err := writeJSONFile(path, rows); err != nil {
+			return written, err
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
+
+// loadFixturesAndAbrigos splits the Abrigo deduplication sheet, if recorded, from the
+// fixtures that go through the parser.
+func loadFixturesAndAbrigos(dir string) ([]*Fixture, map[string]string, error) {
+	all, err := LoadFixtures(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	abrigoMap := map[string]string{}
+	fixtures := make([]*Fixture, 0, len(all))
+	for _, fixture := range all {
+		if fixture.SheetId == AbrigoDeduplicationSheetId {
+			abrigoMap = abrigosMappingFromContent(fixture.Values)
+			continue
+		}
+		fixtures = append(fixtures, fixture)
+	}
+	return fixtures, abrigoMap, nil
+}
+
+func goldenRowFromPessoa(p *objects.PessoaResult) GoldenRow {
+	row := GoldenRow{
+		Nome:       p.Nome,
+		Abrigo:     p.Abrigo,
+		Idade:      p.Idade,
+		Observacao: p.Observacao,
+	}
+	if p.SheetId != nil {
+		row.SheetId = *p.SheetId
+	}
+	if p.URL != nil {
+		row.URL = *p.URL
+	}
+	return row
+}
+
+func firstDifference(want []byte, got []byte) string {
+	wantLines := bytes.Split(bytes.TrimSpace(want), []byte("\n"))
+	gotLines := bytes.Split(bytes.TrimSpace(got), []byte("\n"))
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		var w, g []byte
+		if i < len(wantLines) {
+			w = bytes.TrimSpace(wantLines[i])
+		}
+		if i < len(gotLines) {
+			g = bytes.TrimSpace(gotLines[i])
+		}
+		if !bytes.Equal(w, g) {
+			return fmt.Sprintf("line %d: want %q, got %q", i+1, w, g)
+		}
+	}
+	return ""
+}
+
+func writeJSONFile(path string, v interface{}) error {
+	jsonBytes, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(jsonBytes, '\n'), 0o644)
+}
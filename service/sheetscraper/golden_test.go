@@ -0,0 +1,52 @@
+package sheetscraper
+
+import (
+	"flag"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "Overwrite the golden files with the current parsing results")
+
+// The fixtures and golden files, relative to the package as go test runs from it
+var (
+	testFixturesDir = filepath.Join("testdata", "fixtures")
+	testGoldenDir   = filepath.Join("testdata", "golden")
+)
+
+func TestGoldens(t *testing.T) {
+	if *update {
+		written, err := UpdateGoldens(testFixturesDir, testGoldenDir)
+		if err != nil {
+			t.Fatalf("updating golden files: %v", err)
+		}
+		t.Logf("Wrote %d golden files", len(written))
+		return
+	}
+
+	mismatches, err := VerifyGoldens(testFixturesDir, testGoldenDir)
+	if err != nil {
+		t.Fatalf("verifying golden files: %v", err)
+	}
+	for _, mismatch := range mismatches {
+		t.Error(mismatch)
+	}
+	if len(mismatches) > 0 {
+		t.Log("Run go test ./sheetscraper -run TestGoldens -update after an intentional change")
+	}
+}
+
+// TestMalformedRows checks that a cell that is not text skips its row with an error,
+// while the other rows of the range are still parsed.
+func TestMalformedRows(t *testing.T) {
+	cfg := SheetConfig{id: "10OnXFy-8TtUr3gw9yvtWroI7Z1psXGjdyBA3KMQKstE"}
+	content := [][]interface{}{{"Nº", "NOME"}, {"1", 42.0}, {"2", "Maria Exemplo da Silva"}}
+
+	data, _, err := parseSheet(cfg, "Planilha1!A1:ZZ", content)
+	if err == nil {
+		t.Error("no error for the row with a number")
+	}
+	if len(data) != 1 || data[0].Nome != "Maria Exemplo da Silva" {
+		t.Errorf("parsed %d records, want only the valid row", len(data))
+	}
+}
@@ -0,0 +1,308 @@
+package sheetscraper
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"refugio/objects"
+	"refugio/repository"
+	"refugio/utils/cuckoo"
+
+	cuckoofilter "github.com/panmari/cuckoofilter"
+)
+
+/* Import formats */
+const (
+	ImportCSV   = "csv"
+	ImportJSONL = "jsonl"
+)
+
+// ImportColumns are read from the header of CSV files and the keys of JSON lines,
+// the same names the export writes. Other columns are ignored.
+var ImportColumns = []string{"nome", "abrigo", "idade", "observacao", "sheet_id", "url", "cidade"}
+
+// Every row must have these
+var requiredImportColumns = []string{"nome", "abrigo", "sheet_id"}
+
+// ErrInvalidRows is returned when rows failed validation and the import was not
+// told to skip them. Nothing is written then.
+var ErrInvalidRows = errors.New("some rows are invalid, nothing was imported")
+
+type ImportOptions struct {
+	IsDryRun bool
+	Format   string
+	// Name identifies the file in the plan
+	Name string
+	// SkipInvalid imports the valid rows even when others failed validation
+	SkipInvalid bool
+}
+
+// LineError is a row that could not be imported.
+type LineError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+func (e LineError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// ImportFormat guesses the format from the extension of the file name.
+func ImportFormat(name string) (string, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return ImportCSV, nil
+	case ".jsonl", ".ndjson":
+		return ImportJSONL, nil
+	}
+	return "", fmt.Errorf("cannot tell the format of %s, use --format %s or %s", name, ImportCSV, ImportJSONL)
+}
+
+// Import reads curated records with their source and runs them through the same
+// cleaning, dedup and persistence as the scraped ones. Each row is validated and
+// the rows that failed are returned with their line numbers.
+func Import(ctx context.Context, r io.Reader, opts ImportOptions) (*Plan, []LineError, error) {
+	isDryRun := opts.IsDryRun
+	if os.Getenv("ENVIRONMENT") == "local" && !isDryRun {
+		return nil, nil, fmt.Errorf("cannot run in local environment without dry run")
+	}
+
+	ctx = repository.WithActor(ctx, objects.Actor{Kind: objects.ActorImport, Id: opts.Name})
+
+	rows, lineErrors, err := readImport(r, opts.Format)
+	if err != nil {
+		return nil, lineErrors, err
+	}
+
+	abrigoMap := getAbrigosMapping(ctx)
+	var sheetIds []string
+	bySheet := map[string][]*objects.PessoaResult{}
+	now := time.Now()
+	for _, row := range rows {
+		pessoa, err := row.pessoa(now)
+		if err != nil {
+			lineErrors = append(lineErrors, LineError{Line: row.line, Message: err.Error()})
+			continue
+		}
+		pessoa, isValid := cleanPessoa(pessoa, abrigoMap)
+		if !isValid {
+			lineErrors = append(lineErrors, LineError{Line: row.line, Message: "nome or abrigo is empty after cleaning"})
+			continue
+		}
+		sheetId := *pessoa.SheetId
+		if _, ok := bySheet[sheetId]; !ok {
+			sheetIds = append(sheetIds, sheetId)
+		}
+		bySheet[sheetId] = append(bySheet[sheetId], pessoa)
+	}
+	slices.SortStableFunc(lineErrors, func(a, b LineError) int {
+		return a.Line - b.Line
+	})
+	// A partial import is only made on purpose, the plan still shows what the valid rows would do
+	if len(lineErrors) > 0 && !opts.SkipInvalid {
+		isDryRun = true
+	}
+
+	var filter *cuckoofilter.Filter
+	if !isDryRun {
+		filter, err = cuckoo.LoadOrBuild(ctx, Pessoa)
+		if err != nil {
+			return nil, lineErrors, fmt.Errorf("error getting cuckoo filter: %w", err)
+		}
+	}
+
+	plan := NewPlan(isDryRun)
+	if err := plan.LoadSuppressions(ctx); err != nil {
+		return nil, lineErrors, fmt.Errorf("error getting suppression list: %w", err)
+	}
+	if filter != nil {
+		plan.UseFilter(filter)
+	}
+	filterFailed := 0
+	for _, sheetId := range sheetIds {
+		entries, err := plan.AddRange(ctx, sheetId, opts.Name, bySheet[sheetId])
+		if err != nil {
+			return plan, lineErrors, fmt.Errorf("comparing the rows of %s with stored records: %w", sheetId, err)
+		}
+		if !isDryRun && len(pessoasWithAction(entries, ActionInsert, ActionUpdate)) > 0 {
+			_, failed, err := writeEntries(ctx, filter, entries)
+			filterFailed += failed
+			if err != nil {
+				warnFilterFull(ctx, filter, filterFailed)
+				return plan, lineErrors, fmt.Errorf("importing the rows of %s: %w", sheetId, err)
+			}
+		}
+		if !isDryRun {
+			touchEntries(ctx, entries)
+		}
+	}
+	warnFilterFull(ctx, filter, filterFailed)
+
+	if isDryRun && !opts.IsDryRun {
+		return plan, lineErrors, ErrInvalidRows
+	}
+	return plan, lineErrors, nil
+}
+
+// importRow is a row as read from the file.
+type importRow struct {
+	line   int
+	fields map[string]string
+}
+
+// pessoa checks the source attribution of the row and builds its record.
+func (r importRow) pessoa(now time.Time) (*objects.PessoaResult, error) {
+	for _, column := range requiredImportColumns {
+		if strings.TrimSpace(r.fields[column]) == "" {
+			return nil, fmt.Errorf("%s is empty", column)
+		}
+	}
+	sheetId := strings.TrimSpace(r.fields["sheet_id"])
+	url := strings.TrimSpace(r.fields["url"])
+	pessoa := &objects.PessoaResult{
+		Pessoa: &objects.Pessoa{
+			Nome:       r.fields["nome"],
+			Abrigo:     r.fields["abrigo"],
+			Idade:      strings.TrimSpace(r.fields["idade"]),
+			Observacao: strings.TrimSpace(r.fields["observacao"]),
+		},
+		SheetId:   &sheetId,
+		URL:       &url,
+		Cidade:    strings.TrimSpace(r.fields["cidade"]),
+		Timestamp: now,
+	}
+	if pessoa.Cidade == "" {
+		pessoa.Cidade = configCity(sheetId)
+	}
+	return pessoa, nil
+}
+
+func configCity(sheetId string) string {
+	for _, cfg := range Config {
+		if cfg.id == sheetId {
+			return cfg.city
+		}
+	}
+	return ""
+}
+
+// readImport reads the rows of the file. The rows that cannot be read are returned
+// as line errors, an error is only returned when the file cannot be read at all.
+func readImport(r io.Reader, format string) ([]importRow, []LineError, error) {
+	switch format {
+	case ImportCSV:
+		return readImportCSV(r)
+	case ImportJSONL:
+		return readImportJSONL(r)
+	}
+	return nil, nil, fmt.Errorf("unknown import format %q", format)
+}
+
+func readImportCSV(r io.Reader) ([]importRow, []LineError, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, fmt.Errorf("the file is empty")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading the header: %w", err)
+	}
+	columns := map[int]string{}
+	found := map[string]bool{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[i] = name
+		found[name] = true
+	}
+	for _, column := range requiredImportColumns {
+		if !found[column] {
+			return nil, nil, fmt.Errorf("the header has no %s column", column)
+		}
+	}
+
+	var rows []importRow
+	var lineErrors []LineError
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, lineErrors, nil
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			lineErrors = append(lineErrors, LineError{Line: parseErr.StartLine, Message: parseErr.Err.Error()})
+			continue
+		}
+		if err != nil {
+			return rows, lineErrors, err
+		}
+		line, _ := reader.FieldPos(0)
+		if len(record) != len(header) {
+			lineErrors = append(lineErrors, LineError{Line: line, Message: fmt.Sprintf("has %d fields, the header has %d", len(record), len(header))})
+			continue
+		}
+		row := importRow{line: line, fields: map[string]string{}}
+		for i, value := range record {
+			row.fields[columns[i]] = value
+		}
+		rows = append(rows, row)
+	}
+}
+
+// The longest line read from JSON lines files
+const maxImportLine = 1024 * 1024
+
+func readImportJSONL(r io.Reader) ([]importRow, []LineError, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportLine)
+	var rows []importRow
+	var lineErrors []LineError
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var values map[string]interface{}
+		if err := json.Unmarshal([]byte(text), &values); err != nil {
+			lineErrors = append(lineErrors, LineError{Line: line, Message: "invalid JSON: " + err.Error()})
+			continue
+		}
+		fields, err := jsonFields(values)
+		if err != nil {
+			lineErrors = append(lineErrors, LineError{Line: line, Message: err.Error()})
+			continue
+		}
+		rows = append(rows, importRow{line: line, fields: fields})
+	}
+	if err := scanner.Err(); err != nil {
+		return rows, lineErrors, err
+	}
+	return rows, lineErrors, nil
+}
+
+func jsonFields(values map[string]interface{}) (map[string]string, error) {
+	fields := map[string]string{}
+	for _, column := range ImportColumns {
+		switch value := values[column].(type) {
+		case nil:
+		case string:
+			fields[column] = value
+		case float64:
+			fields[column] = fmt.Sprint(value)
+		default:
+			return nil, fmt.Errorf("%s must be a string", column)
+		}
+	}
+	return fields, nil
+}
@@ -0,0 +1,421 @@
+package sheetscraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"refugio/objects"
+	"refugio/repository"
+
+	cuckoofilter "github.com/panmari/cuckoofilter"
+)
+
+type Action string
+
+const (
+	ActionInsert    Action = "insert"
+	ActionUpdate    Action = "update"
+	ActionDeparted  Action = "departed"
+	ActionDuplicate Action = "duplicate"
+	// ActionSuppressed is a record under an approved remove takedown, it is never written
+	ActionSuppressed Action = "suppressed"
+)
+
+var actions = []Action{ActionInsert, ActionUpdate, ActionDeparted, ActionDuplicate, ActionSuppressed}
+
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+)
+
+// PlanEntry is what a scrape does, or would do in a dry run, with a single record.
+type PlanEntry struct {
+	Action  Action   `json:"action"`
+	Key     string   `json:"key"`
+	SheetId string   `json:"sheetId"`
+	Range   string   `json:"range,omitempty"`
+	Nome    string   `json:"nome"`
+	Abrigo  string   `json:"abrigo"`
+	Changes []string `json:"changes,omitempty"`
+	Reason  string   `json:"reason,omitempty"`
+
+	pessoa *objects.PessoaResult
+	// isStored is set when the record was already stored before this run
+	isStored bool
+}
+
+// Plan compares the scraped records with what is currently stored.
+type Plan struct {
+	IsDryRun bool         `json:"isDryRun"`
+	Entries  []*PlanEntry `json:"entries"`
+	Warnings []string     `json:"warnings,omitempty"`
+
+	seenKeys          map[string]bool
+	knownKeys         *cuckoofilter.Filter
+	suppressions      map[string]*objects.Suppression
+	storeOffline      bool
+	fetchByKeys       func(ctx context.Context, keys []string) (map[string]*objects.PessoaResult, error)
+	fetchByOrigin     func(ctx context.Context, sheetId string) (map[string]*objects.PessoaResult, error)
+	fetchSuppressions func(ctx context.Context) (map[string]*objects.Suppression, error)
+}
+
+type PlanSummary struct {
+	SheetId string         `json:"sheetId"`
+	Counts  map[Action]int `json:"counts"`
+}
+
+func NewPlan(isDryRun bool) *Plan {
+	return &Plan{
+		IsDryRun:          isDryRun,
+		seenKeys:          map[string]bool{},
+		fetchByKeys:       repository.FetchPessoasByKeys,
+		fetchByOrigin:     repository.FetchPessoasByOrigin,
+		fetchSuppressions: repository.FetchSuppressions,
+	}
+}
+
+// LoadSuppressions reads the suppression list, which applies to the records added from
+// now on. Like fetchStored, a dry run that cannot reach the database carries on without it.
+func (p *Plan) LoadSuppressions(ctx context.Context) error {
+	suppressions, err := p.fetchSuppressions(ctx)
+	if err != nil {
+		if !p.IsDryRun {
+			return err
+		}
+		p.Warnings = append(p.Warnings, fmt.Sprintf("could not read the suppression list, taken down records are compared as any other: %v", err))
+		return nil
+	}
+	p.suppressions = suppressions
+	return nil
+}
+
+// UseFilter makes the plan only read the stored version of the keys the filter may
+// know, the others are inserts. A key the filter lost is then written again as an
+// insert, which the write turns into an update and adds back to the filter.
+func (p *Plan) UseFilter(filter *cuckoofilter.Filter) {
+	p.knownKeys = filter
+}
+
+// AddRange classifies the cleaned records of a sheet range and returns the new entries.
+func (p *Plan) AddRange(ctx context.Context, sheetId string, sheetRange string, pessoas []*objects.PessoaResult) ([]*PlanEntry, error) {
+	keys := make([]string, 0, len(pessoas))
+	for _, pessoa := range pessoas {
+		keys = append(keys, pessoa.AggregateKey())
+	}
+
+	lookup := keys
+	if p.knownKeys != nil {
+		lookup = make([]string, 0, len(keys))
+		for _, key := range keys {
+			if p.knownKeys.Lookup([]byte(key)) {
+				lookup = append(lookup, key)
+			}
+		}
+	}
+	stored, err := p.fetchStored(ctx, lookup)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*PlanEntry, 0, len(pessoas))
+	for i, pessoa := range pessoas {
+		key := keys[i]
+		entry := &PlanEntry{
+			Key:     key,
+			SheetId: stringValue(pessoa.SheetId),
+			Range:   sheetRange,
+			Nome:    pessoa.Nome,
+			Abrigo:  pessoa.Abrigo,
+			pessoa:  pessoa,
+		}
+
+		suppression := p.suppressions[key]
+		if suppression != nil && suppression.Kind == objects.TakedownRedact {
+			pessoa.ClearFreeText()
+		}
+		if suppression != nil && suppression.Kind == objects.TakedownCorrect {
+			pessoa.Correct(suppression.Correction)
+		}
+		storedPessoa, isStored := stored[key]
+		entry.isStored = isStored
+		switch {
+		case suppression != nil && suppression.Kind == objects.TakedownRemove:
+			// The names of taken down records are not shown in the plan either
+			entry.Action = ActionSuppressed
+			entry.Nome = ""
+			entry.Abrigo = ""
+			entry.Reason = "takedown " + suppression.TakedownId
+		case p.seenKeys[key]:
+			entry.Action = ActionDuplicate
+			entry.Reason = "already scraped in this run"
+		case !isStored:
+			entry.Action = ActionInsert
+		default:
+			entry.Changes = diffPessoas(storedPessoa, pessoa)
+			if len(entry.Changes) > 0 {
+				entry.Action = ActionUpdate
+			} else {
+				entry.Action = ActionDuplicate
+				entry.Reason = "already stored"
+			}
+		}
+		p.seenKeys[key] = true
+		entries = append(entries, entry)
+	}
+
+	p.Entries = append(p.Entries, entries...)
+	return entries, nil
+}
+
+// AddDeparted marks the stored records read from the ranges of fully scraped sheets that
+// were not seen in this run. Records of the sheet listed by the Planilhão or imported, as
+// well as the ones no scrape saw since the origin of the records is kept, are left alone.
+func (p *Plan) AddDeparted(ctx context.Context, sheetIds []string) ([]*PlanEntry, error) {
+	var entries []*PlanEntry
+	if p.storeOffline {
+		return entries, nil
+	}
+	for _, sheetId := range sheetIds {
+		stored, err := p.fetchByOrigin(ctx, sheetId)
+		if err != nil {
+			return entries, err
+		}
+
+		keys := make([]string, 0, len(stored))
+		for key := range stored {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			pessoa := stored[key]
+			if p.seenKeys[key] || pessoa.Departed {
+				continue
+			}
+			entries = append(entries, &PlanEntry{
+				Action:  ActionDeparted,
+				Key:     key,
+				SheetId: stringValue(pessoa.SheetId),
+				Range:   pessoa.OriginRange,
+				Nome:    pessoa.Nome,
+				Abrigo:  pessoa.Abrigo,
+				pessoa:  pessoa,
+			})
+		}
+	}
+
+	p.Entries = append(p.Entries, entries...)
+	return entries, nil
+}
+
+// fetchStored reads the stored version of the given keys. A dry run that cannot reach
+// the database carries on as if nothing was stored, so it still works offline.
+func (p *Plan) fetchStored(ctx context.Context, keys []string) (map[string]*objects.PessoaResult, error) {
+	if len(keys) == 0 || p.storeOffline {
+		return map[string]*objects.PessoaResult{}, nil
+	}
+	stored, err := p.fetchByKeys(ctx, keys)
+	if err != nil {
+		if !p.IsDryRun {
+			return nil, err
+		}
+		p.storeOffline = true
+		p.Warnings = append(p.Warnings, fmt.Sprintf("could not read stored records, comparing against an empty database: %v", err))
+		return map[string]*objects.PessoaResult{}, nil
+	}
+	return stored, nil
+}
+
+func (p *Plan) Summary() []PlanSummary {
+	bySheet := map[string]map[Action]int{}
+	for _, entry := range p.Entries {
+		if _, ok := bySheet[entry.SheetId]; !ok {
+			bySheet[entry.SheetId] = map[Action]int{}
+		}
+		bySheet[entry.SheetId][entry.Action]++
+	}
+
+	summary := make([]PlanSummary, 0, len(bySheet))
+	for sheetId, counts := range bySheet {
+		summary = append(summary, PlanSummary{SheetId: sheetId, Counts: counts})
+	}
+	sort.Slice(summary, func(i, j int) bool {
+		return summary[i].SheetId < summary[j].SheetId
+	})
+	return summary
+}
+
+// Counts totals the entries by action.
+func (p *Plan) Counts() map[string]int {
+	counts := map[string]int{}
+	for _, entry := range p.Entries {
+		counts[string(entry.Action)]++
+	}
+	return counts
+}
+
+// HasChanges tells whether the plan writes anything to the database.
+func (p *Plan) HasChanges() bool {
+	for _, entry := range p.Entries {
+		if entry.isChange() {
+			return true
+		}
+	}
+	return false
+}
+
+// ChangedNames lists the names of the records the plan inserts, updates or marks departed.
+func (p *Plan) ChangedNames() []string {
+	var nomes []string
+	for _, entry := range p.Entries {
+		if entry.isChange() {
+			nomes = append(nomes, entry.Nome)
+		}
+	}
+	return nomes
+}
+
+// Changed returns the records the plan inserts, updates or marks as departed. Once
+// written they carry the time they were first stored.
+func (p *Plan) Changed() []*objects.PessoaResult {
+	var pessoas []*objects.PessoaResult
+	for _, entry := range p.Entries {
+		if entry.isChange() {
+			pessoas = append(pessoas, entry.pessoa)
+		}
+	}
+	return pessoas
+}
+
+func (e *PlanEntry) isChange() bool {
+	return e.Action != ActionDuplicate && e.Action != ActionSuppressed
+}
+
+func (p *Plan) Write(w io.Writer, format string) error {
+	switch format {
+	case OutputJSON:
+		return p.WriteJSON(w)
+	case OutputTable, "":
+		return p.WriteTable(w)
+	default:
+		return fmt.Errorf("unknown output format %q", format)
+	}
+}
+
+func (p *Plan) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(struct {
+		*Plan
+		Summary []PlanSummary `json:"summary"`
+	}{p, p.Summary()})
+}
+
+// WriteTable lists every change followed by per-sheet totals. Duplicates and suppressed
+// records only show up in the totals, use the JSON output to list them.
+func (p *Plan) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ACTION\tSHEET\tRANGE\tNOME\tABRIGO\tCHANGES")
+	for _, entry := range p.Entries {
+		if !entry.isChange() {
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", entry.Action, entry.SheetId, entry.Range, entry.Nome, entry.Abrigo, strings.Join(entry.Changes, "; "))
+	}
+	fmt.Fprintln(tw)
+
+	fmt.Fprint(tw, "SHEET")
+	for _, action := range actions {
+		fmt.Fprintf(tw, "\t%s", action)
+	}
+	fmt.Fprintln(tw)
+	totals := map[Action]int{}
+	for _, s := range p.Summary() {
+		fmt.Fprint(tw, s.SheetId)
+		for _, action := range actions {
+			fmt.Fprintf(tw, "\t%d", s.Counts[action])
+			totals[action] += s.Counts[action]
+		}
+		fmt.Fprintln(tw)
+	}
+	fmt.Fprint(tw, "TOTAL")
+	for _, action := range actions {
+		fmt.Fprintf(tw, "\t%d", totals[action])
+	}
+	fmt.Fprintln(tw)
+
+	for _, warning := range p.Warnings {
+		slog.Warn(warning)
+	}
+	return tw.Flush()
+}
+
+func diffPessoas(stored *objects.PessoaResult, scraped *objects.PessoaResult) []string {
+	var changes []string
+	compare := func(field string, before string, after string) {
+		if before != after {
+			changes = append(changes, fmt.Sprintf("%s: %q -> %q", field, before, after))
+		}
+	}
+	compare("Nome", stored.Nome, scraped.Nome)
+	compare("Abrigo", stored.Abrigo, scraped.Abrigo)
+	compare("Idade", stored.Idade, scraped.Idade)
+	compare("Observacao", stored.Observacao, scraped.Observacao)
+	compare("SheetId", stringValue(stored.SheetId), stringValue(scraped.SheetId))
+	compare("URL", stringValue(stored.URL), stringValue(scraped.URL))
+	compare("Cidade", stored.Cidade, scraped.Cidade)
+	if stored.Departed && !scraped.Departed {
+		changes = append(changes, "Departed: true -> false")
+	}
+	return changes
+}
+
+func pessoasWithAction(entries []*PlanEntry, actions ...Action) []*objects.PessoaResult {
+	var pessoas []*objects.PessoaResult
+	for _, entry := range entries {
+		for _, action := range actions {
+			if entry.Action == action {
+				pessoas = append(pessoas, entry.pessoa)
+				break
+			}
+		}
+	}
+	return pessoas
+}
+
+func keysWithAction(entries []*PlanEntry, action Action) []string {
+	var keys []string
+	for _, entry := range entries {
+		if entry.Action == action {
+			keys = append(keys, entry.Key)
+		}
+	}
+	return keys
+}
+
+// pessoasUnchanged lists the stored records the entries found as they were, whose last
+// seen time is all that changes.
+func pessoasUnchanged(entries []*PlanEntry) []*objects.PessoaResult {
+	var pessoas []*objects.PessoaResult
+	seen := map[string]bool{}
+	for _, entry := range entries {
+		if entry.Action == ActionDuplicate && entry.isStored && !seen[entry.Key] {
+			seen[entry.Key] = true
+			pessoas = append(pessoas, entry.pessoa)
+		}
+	}
+	return pessoas
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
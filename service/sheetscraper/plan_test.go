@@ -0,0 +1,39 @@
+package sheetscraper
+
+import (
+	"context"
+	"testing"
+
+	"refugio/objects"
+
+	cuckoofilter "github.com/panmari/cuckoofilter"
+)
+
+// TestPlanReadsOnlyKnownKeys checks that with a filter only the keys it knows are read,
+// and that the others are planned as inserts.
+func TestPlanReadsOnlyKnownKeys(t *testing.T) {
+	known := &objects.PessoaResult{Pessoa: &objects.Pessoa{Nome: "Maria da Silva", Abrigo: "FAPA"}}
+	novel := &objects.PessoaResult{Pessoa: &objects.Pessoa{Nome: "Joao Souza", Abrigo: "FAPA"}}
+	filter := cuckoofilter.NewFilter(1000)
+	filter.Insert([]byte(known.AggregateKey()))
+
+	var read []string
+	plan := NewPlan(false)
+	plan.fetchByKeys = func(ctx context.Context, keys []string) (map[string]*objects.PessoaResult, error) {
+		read = append(read, keys...)
+		stored := &objects.PessoaResult{Pessoa: &objects.Pessoa{Nome: known.Nome, Abrigo: known.Abrigo}}
+		return map[string]*objects.PessoaResult{known.AggregateKey(): stored}, nil
+	}
+	plan.UseFilter(filter)
+
+	entries, err := plan.AddRange(context.Background(), "sheet", "A1:C", []*objects.PessoaResult{known, novel})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(read) != 1 || read[0] != known.AggregateKey() {
+		t.Errorf("read %v, want only %s", read, known.AggregateKey())
+	}
+	if entries[0].Action != ActionDuplicate || entries[1].Action != ActionInsert {
+		t.Errorf("actions %s and %s, want %s and %s", entries[0].Action, entries[1].Action, ActionDuplicate, ActionInsert)
+	}
+}
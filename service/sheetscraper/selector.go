@@ -0,0 +1,55 @@
+package sheetscraper
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Selector limits a scrape to part of Config. The zero value selects everything.
+type Selector struct {
+	// SourceIds keeps only these spreadsheet ids
+	SourceIds []string
+	// Ranges keeps only these tabs, matched by tab name
+	Ranges []string
+	// NameMatch keeps only the sources whose name matches
+	NameMatch *regexp.Regexp
+	// Exclude drops these spreadsheet ids, even if selected otherwise
+	Exclude []string
+	// IncludeDisabled also scrapes the sources disabled after failing, which are
+	// enabled again when they are read
+	IncludeDisabled bool
+}
+
+func (s Selector) MatchSource(cfg SheetConfig) bool {
+	if slices.Contains(s.Exclude, cfg.id) {
+		return false
+	}
+	if len(s.SourceIds) > 0 && !slices.Contains(s.SourceIds, cfg.id) {
+		return false
+	}
+	if s.NameMatch != nil && !s.NameMatch.MatchString(cfg.name) {
+		return false
+	}
+	return true
+}
+
+func (s Selector) MatchRange(sheetRange string) bool {
+	if len(s.Ranges) == 0 {
+		return true
+	}
+	for _, r := range s.Ranges {
+		if r == sheetRange || strings.EqualFold(strings.TrimSpace(TabName(r)), strings.TrimSpace(TabName(sheetRange))) {
+			return true
+		}
+	}
+	return false
+}
+
+// TabName strips the cell range from a sheet range, "Página1!A1:ZZ" becomes "Página1".
+func TabName(sheetRange string) string {
+	if i := strings.LastIndex(sheetRange, "!"); i >= 0 {
+		sheetRange = sheetRange[:i]
+	}
+	return strings.Trim(sheetRange, "'")
+}
@@ -0,0 +1,65 @@
+package sheetscraper
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+
+	"refugio/objects"
+	"refugio/repository"
+)
+
+// DefaultMaxSourceFailures is how many scrapes in a row may fail to read a source
+// before it is disabled, unless SOURCE_MAX_FAILURES says otherwise.
+const DefaultMaxSourceFailures = 5
+
+// MaxSourceFailures reads SOURCE_MAX_FAILURES, zero never disabling a source.
+func MaxSourceFailures() int {
+	if n, err := strconv.Atoi(os.Getenv("SOURCE_MAX_FAILURES")); err == nil && n >= 0 {
+		return n
+	}
+	return DefaultMaxSourceFailures
+}
+
+// disabledSources returns the health of the disabled sources, keyed by spreadsheet id.
+// When the sources cannot be read every source is scraped, the ones that still fail
+// are disabled again by their next failures.
+func disabledSources(ctx context.Context, plan *Plan) map[string]objects.SourceHealth {
+	disabled := map[string]objects.SourceHealth{}
+	sources, err := repository.FetchSourcesFromFirestore(ctx)
+	if err != nil {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("could not read the health of the sources, disabled sources are scraped as well: %v", err))
+		return disabled
+	}
+	for _, source := range sources {
+		if source.Configured && source.Health.Disabled {
+			disabled[source.SheetId] = source.Health
+		}
+	}
+	return disabled
+}
+
+// recordSourceHealth stores whether the source could be read, alerting when it gets
+// disabled. A failure to store it is only logged, the scrape carries on.
+func recordSourceHealth(ctx context.Context, source *objects.Source, readErr error) {
+	health, disabled, err := repository.RecordSourceScrape(ctx, source, readErr, MaxSourceFailures())
+	if err != nil {
+		return
+	}
+	if disabled {
+		slog.WarnContext(ctx, "Source disabled after failing repeatedly", "sheet_id", source.SheetId, "failures", health.Failures, "last_error", health.LastError)
+		notifySourceDisabled(ctx, source, health)
+	}
+}
+
+// SetSourceDisabled disables or enables one of the configured sources.
+func SetSourceDisabled(ctx context.Context, sheetId string, disabled bool) (objects.SourceHealth, error) {
+	for _, cfg := range Config {
+		if cfg.id == sheetId {
+			return repository.SetSourceDisabled(ctx, cfg.source(), disabled)
+		}
+	}
+	return objects.SourceHealth{}, fmt.Errorf("no configured source has the id %s", sheetId)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"refugio/repository"
+	"refugio/sheetscraper"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+var sourcesCmd = &cobra.Command{
+	Use:   "sources",
+	Short: "Show where the sources are from and whether they can be read",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sources, err := repository.FetchSourcesFromFirestore(cmd.Context())
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "SHEET\tNOME\tCIDADE\tREGIAO\tMODIFIED\tSTATUS\tFAILURES\tLAST SUCCESS\tLAST ERROR")
+		for _, source := range sources {
+			// The sources listed by the Planilhão are not scraped on their own
+			if !source.Configured {
+				continue
+			}
+			health := source.Health
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\t%s\n", source.SheetId, source.Nome, source.Cidade, source.Regiao, formatOptionalTime(source.ModifiedAt), health.Status(), health.Failures, formatOptionalTime(health.LastSuccessAt), health.LastError)
+		}
+		return tw.Flush()
+	},
+}
+
+var sourcesEnableCmd = &cobra.Command{
+	Use:   "enable <sheet id>",
+	Short: "Enable a source disabled after failing, clearing its failures",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := sheetscraper.SetSourceDisabled(cmd.Context(), args[0], false); err != nil {
+			return err
+		}
+		fmt.Printf("Enabled source %s\n", args[0])
+		return nil
+	},
+}
+
+var sourcesDisableCmd = &cobra.Command{
+	Use:   "disable <sheet id>",
+	Short: "Disable a source, so that the scrapes skip it",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := sheetscraper.SetSourceDisabled(cmd.Context(), args[0], true); err != nil {
+			return err
+		}
+		fmt.Printf("Disabled source %s\n", args[0])
+		return nil
+	},
+}
+
+func init() {
+	sourcesCmd.AddCommand(sourcesEnableCmd, sourcesDisableCmd)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"refugio/api"
+	"refugio/objects"
+	"refugio/repository"
+	"refugio/scheduler"
+	"refugio/stats"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show the statistics of the stored records",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		values := url.Values{}
+		for _, name := range []string{"from", "to", "group-by"} {
+			value, _ := cmd.Flags().GetString(name)
+			values.Set(strings.ReplaceAll(name, "-", "_"), value)
+		}
+		query, err := stats.ParseQuery(values)
+		if err != nil {
+			return err
+		}
+		days, err := repository.FetchStatsDays(cmd.Context(), query.From, query.To)
+		if err != nil {
+			return err
+		}
+
+		result := api.NewStats(query, days)
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintf(tw, "%s\tTOTAL\tDEPARTED\n", strings.ToUpper(strings.Join(query.GroupBy, "\t")))
+		for _, row := range result.Series {
+			var columns []string
+			for _, dimension := range query.GroupBy {
+				columns = append(columns, map[string]string{
+					stats.Day:    row.Day,
+					stats.Source: row.SheetId,
+					stats.Abrigo: row.Abrigo,
+					stats.Cidade: row.Cidade,
+				}[dimension])
+			}
+			fmt.Fprintf(tw, "%s\t%d\t%d\n", strings.Join(columns, "\t"), row.Total, row.Departed)
+		}
+		fmt.Fprintf(tw, "\nTotal: %d, departed: %d\n", result.Total, result.Departed)
+		return tw.Flush()
+	},
+}
+
+var statsRebuildCmd = &cobra.Command{
+	Use:   "rebuild",
+	Short: "Recompute the statistics from the stored records",
+	Long:  "Recompute the statistics from the stored records. Scrapes that change records already do it; this is for changes made some other way. It holds the scrape lock.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var days []*objects.StatsDay
+		err := scheduler.WithLock(cmd.Context(), func(ctx context.Context) error {
+			var err error
+			days, err = stats.Rebuild(ctx)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Computed statistics for %d days\n", len(days))
+		return nil
+	},
+}
+
+func init() {
+	statsCmd.Flags().String("from", "", "First day, as 2006-01-02")
+	statsCmd.Flags().String("to", "", "Last day, as 2006-01-02")
+	statsCmd.Flags().String("group-by", stats.Day, "Dimensions separated by commas: "+strings.Join(stats.Dimensions, ", "))
+	statsCmd.AddCommand(statsRebuildCmd)
+}
@@ -0,0 +1,263 @@
+// Package stats computes how many records were registered per day, source, abrigo
+// and city. The days a scrape changes are computed after it and stored, the requests
+// only aggregate them.
+package stats
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"refugio/objects"
+	"refugio/repository"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Location is where the days start and end. Rio Grande do Sul has had no daylight
+// saving time since 2019.
+var Location = time.FixedZone("BRT", -3*60*60)
+
+const DateLayout = time.DateOnly
+
+/* Grouping dimensions */
+const (
+	Day    = "day"
+	Source = "source"
+	Abrigo = "abrigo"
+	Cidade = "cidade"
+)
+
+var Dimensions = []string{Day, Source, Abrigo, Cidade}
+
+// Compute splits the records by the day they were first stored and by their source,
+// abrigo and city, so that later writes of a record do not move it to another day.
+// Records without a timestamp are left out.
+func Compute(pessoas []*objects.PessoaResult, computedAt time.Time) []*objects.StatsDay {
+	type groupKey struct {
+		date    string
+		sheetId string
+		abrigo  string
+		cidade  string
+	}
+	groups := map[groupKey]*objects.StatsGroup{}
+	for _, pessoa := range pessoas {
+		created := pessoa.Created()
+		if created.IsZero() {
+			continue
+		}
+		key := groupKey{
+			date:    created.In(Location).Format(DateLayout),
+			sheetId: dimensionValue(stringValue(pessoa.SheetId)),
+			cidade:  dimensionValue(pessoa.Cidade),
+		}
+		if pessoa.Pessoa != nil {
+			key.abrigo = dimensionValue(pessoa.Abrigo)
+		}
+		group, ok := groups[key]
+		if !ok {
+			group = &objects.StatsGroup{SheetId: key.sheetId, Abrigo: key.abrigo, Cidade: key.cidade}
+			groups[key] = group
+		}
+		group.Total++
+		if pessoa.Departed {
+			group.Departed++
+		}
+	}
+
+	byDate := map[string]*objects.StatsDay{}
+	for key, group := range groups {
+		day, ok := byDate[key.date]
+		if !ok {
+			day = &objects.StatsDay{Date: key.date, ComputedAt: computedAt}
+			byDate[key.date] = day
+		}
+		day.Total += group.Total
+		day.Groups = append(day.Groups, *group)
+	}
+
+	days := make([]*objects.StatsDay, 0, len(byDate))
+	for _, day := range byDate {
+		slices.SortFunc(day.Groups, func(a, b objects.StatsGroup) int {
+			return cmp.Or(cmp.Compare(a.SheetId, b.SheetId), cmp.Compare(a.Abrigo, b.Abrigo), cmp.Compare(a.Cidade, b.Cidade))
+		})
+		days = append(days, day)
+	}
+	slices.SortFunc(days, func(a, b *objects.StatsDay) int {
+		return cmp.Compare(a.Date, b.Date)
+	})
+	return days
+}
+
+// Rebuild computes the days from every stored record and replaces the stored ones.
+func Rebuild(ctx context.Context) ([]*objects.StatsDay, error) {
+	pessoas, err := repository.FetchPessoasForStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	days := Compute(pessoas, time.Now())
+	if err := repository.ReplaceStatsDays(ctx, days); err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Statistics rebuilt", "records", len(pessoas), "days", len(days))
+	return days, nil
+}
+
+// Refresh recomputes the days the records were created on from the records stored on
+// them, and replaces the stored days. The records are the ones a scrape, an import or
+// a purge changed, so only the days they touch are read.
+func Refresh(ctx context.Context, pessoas []*objects.PessoaResult) ([]*objects.StatsDay, error) {
+	touched := map[string]bool{}
+	for _, pessoa := range pessoas {
+		if created := pessoa.Created(); !created.IsZero() {
+			touched[created.In(Location).Format(DateLayout)] = true
+		}
+	}
+	dates := make([]string, 0, len(touched))
+	for date := range touched {
+		dates = append(dates, date)
+	}
+	slices.Sort(dates)
+
+	now := time.Now()
+	var days []*objects.StatsDay
+	for _, date := range dates {
+		start, err := time.ParseInLocation(DateLayout, date, Location)
+		if err != nil {
+			return nil, err
+		}
+		created, err := repository.FetchPessoasCreatedBetween(ctx, start, start.AddDate(0, 0, 1))
+		if err != nil {
+			return nil, err
+		}
+		days = append(days, Compute(created, now)...)
+	}
+	if err := repository.UpdateStatsDays(ctx, dates, days); err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Statistics refreshed", "days", len(dates))
+	return days, nil
+}
+
+// Query selects the days and how they are grouped.
+type Query struct {
+	From    string
+	To      string
+	GroupBy []string
+}
+
+// ErrInvalidQuery is wrapped by the errors of ParseQuery, whose messages can be shown
+// to the users.
+var ErrInvalidQuery = errors.New("invalid query")
+
+type queryError struct {
+	message string
+}
+
+func (e *queryError) Error() string {
+	return e.message
+}
+
+func (e *queryError) Unwrap() error {
+	return ErrInvalidQuery
+}
+
+// ParseQuery reads from, to and group_by. group_by may be repeated or separated by
+// commas, and defaults to day.
+func ParseQuery(values url.Values) (Query, error) {
+	query := Query{From: values.Get("from"), To: values.Get("to")}
+	for _, bound := range []struct{ name, value string }{{"from", query.From}, {"to", query.To}} {
+		if bound.value == "" {
+			continue
+		}
+		if _, err := time.Parse(DateLayout, bound.value); err != nil {
+			return query, &queryError{fmt.Sprintf("A data %s deve estar no formato AAAA-MM-DD.", bound.name)}
+		}
+	}
+	if query.From != "" && query.To != "" && query.From > query.To {
+		return query, &queryError{"A data from deve ser anterior ou igual à data to."}
+	}
+
+	for _, value := range values["group_by"] {
+		for _, dimension := range strings.Split(value, ",") {
+			dimension = strings.TrimSpace(dimension)
+			if dimension == "" || slices.Contains(query.GroupBy, dimension) {
+				continue
+			}
+			if !slices.Contains(Dimensions, dimension) {
+				return query, &queryError{fmt.Sprintf("Não é possível agrupar por %q, use %s.", dimension, strings.Join(Dimensions, ", "))}
+			}
+			query.GroupBy = append(query.GroupBy, dimension)
+		}
+	}
+	if len(query.GroupBy) == 0 {
+		query.GroupBy = []string{Day}
+	}
+	return query, nil
+}
+
+// Row is one aggregate of the series, only the grouped dimensions are set.
+type Row struct {
+	Day      string
+	SheetId  string
+	Abrigo   string
+	Cidade   string
+	Total    int
+	Departed int
+}
+
+// Aggregate sums the groups of the days by the dimensions, ordered by them.
+func Aggregate(days []*objects.StatsDay, groupBy []string) []Row {
+	rows := map[Row]*Row{}
+	for _, day := range days {
+		for _, group := range day.Groups {
+			var key Row
+			for _, dimension := range groupBy {
+				switch dimension {
+				case Day:
+					key.Day = day.Date
+				case Source:
+					key.SheetId = group.SheetId
+				case Abrigo:
+					key.Abrigo = group.Abrigo
+				case Cidade:
+					key.Cidade = group.Cidade
+				}
+			}
+			row, ok := rows[key]
+			if !ok {
+				copied := key
+				row = &copied
+				rows[key] = row
+			}
+			row.Total += group.Total
+			row.Departed += group.Departed
+		}
+	}
+
+	series := make([]Row, 0, len(rows))
+	for _, row := range rows {
+		series = append(series, *row)
+	}
+	slices.SortFunc(series, func(a, b Row) int {
+		return cmp.Or(cmp.Compare(a.Day, b.Day), cmp.Compare(a.SheetId, b.SheetId), cmp.Compare(a.Abrigo, b.Abrigo), cmp.Compare(a.Cidade, b.Cidade))
+	})
+	return series
+}
+
+func dimensionValue(value string) string {
+	if value == "" {
+		return repository.UnknownCounterKey
+	}
+	return value
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/user"
+	"refugio/objects"
+	"refugio/repository"
+	"refugio/takedown"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var takedownCmd = &cobra.Command{
+	Use:   "takedown",
+	Short: "Manage the requests to remove, redact or correct a record",
+}
+
+var takedownListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the takedown requests",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		status, _ := cmd.Flags().GetString("status")
+		takedowns, err := repository.FetchTakedowns(cmd.Context(), status)
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tRECORD\tKIND\tSTATUS\tCREATED\tREASON")
+		for _, t := range takedowns {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", t.Id, t.Key, t.Kind, t.Status, t.CreatedAt.Format(time.DateTime), t.Reason)
+		}
+		return tw.Flush()
+	},
+}
+
+var takedownRequestCmd = &cobra.Command{
+	Use:   "request <record id>",
+	Short: "Store a takedown request for a record",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		kind, _ := cmd.Flags().GetString("kind")
+		reason, _ := cmd.Flags().GetString("reason")
+		contact, _ := cmd.Flags().GetString("contact")
+		var correction *objects.Correction
+		if cmd.Flags().Changed("idade") || cmd.Flags().Changed("observacao") {
+			correction = &objects.Correction{}
+			correction.Idade, _ = cmd.Flags().GetString("idade")
+			correction.Observacao, _ = cmd.Flags().GetString("observacao")
+		}
+		created, err := takedown.Request(cliContext(cmd.Context()), args[0], kind, correction, reason, contact)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Requested takedown %s, approve it with takedown approve %s\n", created.Id, created.Id)
+		return nil
+	},
+}
+
+var takedownApproveCmd = &cobra.Command{
+	Use:   "approve <id>",
+	Short: "Approve a takedown and apply it to the record",
+	Long:  "Approve a takedown, delete, redact or correct its record and its history and put the record on the suppression list, so that scrapes and imports do not write it back. It holds the scrape lock. The web servers drop their cached responses within a minute.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		note, _ := cmd.Flags().GetString("note")
+		approved, err := takedown.Approve(cliContext(cmd.Context()), args[0], note)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Approved takedown %s, record %s is now under %s\n", approved.Id, approved.Key, approved.Kind)
+		return nil
+	},
+}
+
+var takedownRejectCmd = &cobra.Command{
+	Use:   "reject <id>",
+	Short: "Reject a takedown, leaving the record as it is",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		note, _ := cmd.Flags().GetString("note")
+		rejected, err := takedown.Reject(cliContext(cmd.Context()), args[0], note)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Rejected takedown %s\n", rejected.Id)
+		return nil
+	},
+}
+
+// cliContext attributes the writes of the command to the user running it.
+func cliContext(ctx context.Context) context.Context {
+	actor := objects.Actor{Kind: objects.ActorCLI}
+	if current, err := user.Current(); err == nil {
+		actor.Id = current.Username
+	}
+	return repository.WithActor(ctx, actor)
+}
+
+func init() {
+	takedownListCmd.Flags().String("status", objects.TakedownPending, "Only the requests with this status, all of them when empty")
+	takedownRequestCmd.Flags().String("kind", objects.TakedownRemove, "What to do with the record: "+strings.Join(objects.TakedownKinds, " or "))
+	takedownRequestCmd.Flags().String("idade", "", "The correct idade, for the correct kind")
+	takedownRequestCmd.Flags().String("observacao", "", "The correct observacao, for the correct kind")
+	takedownRequestCmd.Flags().String("reason", "", "Why the record should be taken down")
+	takedownRequestCmd.Flags().String("contact", "", "How to reach who asked for it")
+	takedownApproveCmd.Flags().String("note", "", "Note kept with the decision")
+	takedownRejectCmd.Flags().String("note", "", "Note kept with the decision")
+	takedownCmd.AddCommand(takedownListCmd, takedownRequestCmd, takedownApproveCmd, takedownRejectCmd)
+}
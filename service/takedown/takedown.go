@@ -0,0 +1,144 @@
+// Package takedown handles the requests to remove, redact or correct a published
+// record. A request is stored as pending, and once an admin approves it the record and
+// its history are changed and its key goes on the suppression list the scrapes and
+// imports consult.
+package takedown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"refugio/objects"
+	"refugio/repository"
+	"refugio/scheduler"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	MaxReasonLength  = 2000
+	MaxContactLength = 200
+	// MaxCorrectionLength applies to each corrected field
+	MaxCorrectionLength = 500
+)
+
+var (
+	// ErrInvalidRequest is wrapped by the errors of Request about its arguments, whose
+	// messages can be shown to the users.
+	ErrInvalidRequest = errors.New("invalid takedown request")
+	ErrUnknownRecord  = errors.New("no record with the id")
+	ErrNotFound       = errors.New("no takedown with the id")
+)
+
+type requestError struct {
+	message string
+}
+
+func (e *requestError) Error() string {
+	return e.message
+}
+
+func (e *requestError) Unwrap() error {
+	return ErrInvalidRequest
+}
+
+// Request stores a pending takedown of the record with the key. The correction is
+// required for the correct takedowns and refused for the others.
+func Request(ctx context.Context, key string, kind string, correction *objects.Correction, reason string, contact string) (*objects.Takedown, error) {
+	reason = strings.TrimSpace(reason)
+	contact = strings.TrimSpace(contact)
+	if correction != nil {
+		correction = &objects.Correction{Idade: strings.TrimSpace(correction.Idade), Observacao: strings.TrimSpace(correction.Observacao)}
+	}
+	switch {
+	case strings.TrimSpace(key) == "":
+		return nil, &requestError{"Informe o id do registro."}
+	case !slices.Contains(objects.TakedownKinds, kind):
+		return nil, &requestError{fmt.Sprintf("O tipo %q não existe, use %s.", kind, strings.Join(objects.TakedownKinds, " ou "))}
+	case kind == objects.TakedownCorrect && correction == nil:
+		return nil, &requestError{"Informe a idade e as observações corretas."}
+	case kind != objects.TakedownCorrect && correction != nil:
+		return nil, &requestError{fmt.Sprintf("A correção só é aceita nos pedidos do tipo %s.", objects.TakedownCorrect)}
+	case correction != nil && (utf8.RuneCountInString(correction.Idade) > MaxCorrectionLength || utf8.RuneCountInString(correction.Observacao) > MaxCorrectionLength):
+		return nil, &requestError{fmt.Sprintf("A idade e as observações corretas devem ter no máximo %d caracteres.", MaxCorrectionLength)}
+	case reason == "":
+		return nil, &requestError{"Informe o motivo do pedido."}
+	case utf8.RuneCountInString(reason) > MaxReasonLength:
+		return nil, &requestError{fmt.Sprintf("O motivo deve ter no máximo %d caracteres.", MaxReasonLength)}
+	case utf8.RuneCountInString(contact) > MaxContactLength:
+		return nil, &requestError{fmt.Sprintf("O contato deve ter no máximo %d caracteres.", MaxContactLength)}
+	}
+
+	pessoas, err := repository.FetchPessoaFromFirestore(ctx, []string{key})
+	if err != nil {
+		return nil, err
+	}
+	if len(pessoas) == 0 {
+		return nil, ErrUnknownRecord
+	}
+
+	now := time.Now()
+	takedown := &objects.Takedown{
+		Key:        key,
+		Kind:       kind,
+		Correction: correction,
+		Reason:     reason,
+		Contact:    contact,
+		Status:     objects.TakedownPending,
+		Events:     []objects.TakedownEvent{{Action: objects.TakedownRequested, Actor: repository.ActorFrom(ctx), Timestamp: now}},
+		CreatedAt:  now,
+	}
+	if err := repository.AddTakedown(ctx, takedown); err != nil {
+		return nil, err
+	}
+	// The reason, the contact and the correction are personal information, they stay out of the logs
+	slog.InfoContext(ctx, "Takedown requested", "takedown", takedown.Id, "kind", kind, "actor", repository.ActorFrom(ctx).String())
+	return takedown, nil
+}
+
+// Approve approves a pending takedown and applies it to the record and its history,
+// under the scrape lock so that no running scrape writes the record back. The cache of
+// every instance is then marked to be purged. Approving a takedown that was already
+// approved does it again, for when that failed.
+func Approve(ctx context.Context, id string, note string) (*objects.Takedown, error) {
+	var takedown *objects.Takedown
+	err := scheduler.WithLock(ctx, func(ctx context.Context) error {
+		var err error
+		takedown, err = repository.DecideTakedown(ctx, id, objects.TakedownApproved, note)
+		if errors.Is(err, repository.ErrTakedownDecided) && takedown.Status == objects.TakedownApproved {
+			slog.InfoContext(ctx, "Takedown was already approved, applying it again", "takedown", id)
+		} else if err != nil {
+			return err
+		}
+		if takedown == nil {
+			return ErrNotFound
+		}
+
+		if err := repository.ApplyTakedown(ctx, takedown); err != nil {
+			return fmt.Errorf("the takedown was approved but not applied to the record, approve it again: %w", err)
+		}
+		// The record may be in the cached search responses of any instance
+		if err := repository.MarkCachePurged(ctx, time.Now()); err != nil {
+			return fmt.Errorf("the takedown was applied but the cache was not purged, approve it again: %w", err)
+		}
+		slog.InfoContext(ctx, "Takedown approved", "takedown", id, "kind", takedown.Kind, "actor", repository.ActorFrom(ctx).String())
+		return nil
+	})
+	return takedown, err
+}
+
+// Reject rejects a pending takedown, the record is left as it is.
+func Reject(ctx context.Context, id string, note string) (*objects.Takedown, error) {
+	takedown, err := repository.DecideTakedown(ctx, id, objects.TakedownRejected, note)
+	if err != nil {
+		return takedown, err
+	}
+	if takedown == nil {
+		return nil, ErrNotFound
+	}
+	slog.InfoContext(ctx, "Takedown rejected", "takedown", id, "actor", repository.ActorFrom(ctx).String())
+	return takedown, nil
+}
@@ -0,0 +1,54 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// Tokens look like "rfg_<id>_<secret>". Only the hash of the secret is stored, the id
+// is used to find the stored key without scanning every key.
+const prefix = "rfg_"
+
+func Generate() (id string, secret string, token string) {
+	id = randomString(6)
+	secret = randomString(24)
+	return id, secret, Format(id, secret)
+}
+
+func Format(id string, secret string) string {
+	return prefix + id + "_" + secret
+}
+
+func Parse(token string) (id string, secret string, ok bool) {
+	if !strings.HasPrefix(token, prefix) {
+		return "", "", false
+	}
+	id, secret, ok = strings.Cut(strings.TrimPrefix(token, prefix), "_")
+	if !ok || id == "" || secret == "" {
+		return "", "", false
+	}
+	return id, secret, true
+}
+
+func Hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// Verify compares in constant time so the response time does not leak the stored hash.
+func Verify(secret string, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(Hash(secret)), []byte(hash)) == 1
+}
+
+func randomString(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	// No "_" in the alphabet, it separates the id from the secret
+	return strings.ReplaceAll(base64.RawURLEncoding.EncodeToString(b), "_", "-")
+}
@@ -1,33 +1,123 @@
 package cuckoo
 
 import (
+	"context"
 	"fmt"
-	"os"
+	"log/slog"
 	"refugio/repository"
 
 	cuckoo "github.com/panmari/cuckoofilter"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var DEFAULT_CUCKOO_CAPACITY uint = 250000
 
+// Filters are sized for twice the keys they start with, so that they keep accepting
+// inserts for a while before they need a rebuild
+const capacityHeadroom = 2
+
+// MaxLoadFactor is where inserts start failing often and the filter should be rebuilt.
+const MaxLoadFactor = 0.9
+
 func createCuckooFilter(capacity uint) *cuckoo.Filter {
 	filter := cuckoo.NewFilter(capacity)
 
 	return filter
 }
 
-func GetCuckooFilter(key string) (*cuckoo.Filter, error) {
-	filterBytes, err := repository.FetchFilterFromFirestore(key)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error fetching filter from Firestore: %v. Creating from scratch\n", err)
+// Capacity is the capacity of a filter that will start with the given number of keys.
+func Capacity(keys int) uint {
+	return max(DEFAULT_CUCKOO_CAPACITY, uint(keys)*capacityHeadroom)
+}
+
+// GetCuckooFilter loads the stored filter. Only a filter that was never stored starts
+// empty, any other failure is returned so that the stored filter is not overwritten
+// by one that lost its keys.
+func GetCuckooFilter(ctx context.Context, key string) (*cuckoo.Filter, error) {
+	filterBytes, err := repository.FetchFilterFromFirestore(ctx, key)
+	if status.Code(err) == codes.NotFound {
+		slog.WarnContext(ctx, "No filter stored, creating from scratch", "filter", key)
 		return createCuckooFilter(DEFAULT_CUCKOO_CAPACITY), nil
 	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching filter %s: %w", key, err)
+	}
 
 	filter, err := cuckoo.Decode(filterBytes)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error decoding filter: %v\n", err)
+		slog.ErrorContext(ctx, "Error decoding filter", "filter", key, "error", err)
 		return nil, err
 	}
 
 	return filter, nil
 }
+
+// LoadOrBuild loads the stored filter, or builds one from the stored records when none
+// was ever stored. Runs skip reading the records the filter does not know, so unlike
+// GetCuckooFilter it never returns an empty filter while records are stored.
+func LoadOrBuild(ctx context.Context, key string) (*cuckoo.Filter, error) {
+	filterBytes, err := repository.FetchFilterFromFirestore(ctx, key)
+	if status.Code(err) == codes.NotFound {
+		keys, err := repository.FetchPessoaKeys(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing the keys for filter %s: %w", key, err)
+		}
+		slog.WarnContext(ctx, "No filter stored, building it from the stored records", "filter", key, "keys", len(keys))
+		filter, failed := Build(keys)
+		if len(failed) > 0 {
+			return nil, fmt.Errorf("building filter %s: %d keys did not fit", key, len(failed))
+		}
+		return filter, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching filter %s: %w", key, err)
+	}
+
+	filter, err := cuckoo.Decode(filterBytes)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error decoding filter", "filter", key, "error", err)
+		return nil, err
+	}
+
+	return filter, nil
+}
+
+// Build creates a filter sized for the keys and inserts them. It returns the keys
+// that did not fit.
+func Build(keys []string) (*cuckoo.Filter, []string) {
+	filter := createCuckooFilter(Capacity(len(keys)))
+	var failed []string
+	for _, key := range keys {
+		if !filter.Insert([]byte(key)) {
+			failed = append(failed, key)
+		}
+	}
+	return filter, failed
+}
+
+// Drift compares a filter with the keys that are actually stored.
+type Drift struct {
+	Keys int
+	// Missing are stored keys the filter does not know
+	Missing []string
+	// Stale estimates the entries of the filter for keys that are no longer stored
+	Stale      int
+	Count      uint
+	LoadFactor float64
+}
+
+func (d Drift) HasDrift() bool {
+	return len(d.Missing) > 0 || d.Stale > 0
+}
+
+func Verify(filter *cuckoo.Filter, keys []string) Drift {
+	drift := Drift{Keys: len(keys), Count: filter.Count(), LoadFactor: filter.LoadFactor()}
+	for _, key := range keys {
+		if !filter.Lookup([]byte(key)) {
+			drift.Missing = append(drift.Missing, key)
+		}
+	}
+	drift.Stale = max(0, int(filter.Count())-(len(keys)-len(drift.Missing)))
+	return drift
+}
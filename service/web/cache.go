@@ -0,0 +1,281 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"path"
+	"refugio/metrics"
+	"refugio/repository"
+	"refugio/tracing"
+	"refugio/utils"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// searchPaths are the routes whose responses depend on the searched nome
+var searchPaths = map[string]bool{"/pessoa": true, "/v1/pessoa": true}
+
+// CachePurgeCheckEvery is how often an instance looks for the purges of the others
+const CachePurgeCheckEvery = 30 * time.Second
+
+var cache = expirable.NewLRU[string, *cacheEntry](50000, nil, time.Minute*30)
+
+// Headers that belong to the request being served rather than to the response content,
+// along with the RateLimit-* and X-Cache ones
+var uncachedHeaders = []string{
+	"Access-Control-Allow-Headers", "Access-Control-Allow-Origin", "Cache-Control", "Retry-After", "Set-Cookie",
+	// The ids of the request and its trace, which the access log and error bodies carry
+	"X-Request-Id", "Traceparent", "Tracestate", "Traceresponse", "X-Cloud-Trace-Context",
+}
+
+type cacheEntry struct {
+	Status int
+	Header http.Header
+	Body   []byte
+	ETag   string
+
+	// Path and the words searched, used to find the entries a change affects
+	Path  string
+	Words []string
+}
+
+// ResponseCapture buffers a response so it can be inspected before it is sent.
+type ResponseCapture struct {
+	http.ResponseWriter
+	Status int
+	Body   bytes.Buffer
+}
+
+func (w *ResponseCapture) WriteHeader(status int) {
+	if w.Status == 0 {
+		w.Status = status
+	}
+}
+
+func (w *ResponseCapture) Write(data []byte) (int, error) {
+	if w.Status == 0 {
+		w.Status = http.StatusOK
+	}
+	return w.Body.Write(data)
+}
+
+func CacheMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			return
+		}
+		if r.Method != http.MethodGet {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cacheKey := CacheKey(r.URL)
+		_, span := tracing.Start(r.Context(), "cache.Lookup")
+		entry, ok := cache.Get(cacheKey)
+		span.SetAttributes(attribute.Bool("cache.hit", ok))
+		span.End()
+		if ok {
+			metrics.CacheRequests.WithLabelValues("hit").Inc()
+			w.Header().Set("X-Cache", "HIT")
+			writeCacheEntry(w, r, entry)
+			return
+		}
+
+		metrics.CacheRequests.WithLabelValues("miss").Inc()
+		capture := &ResponseCapture{ResponseWriter: w}
+		next.ServeHTTP(capture, r)
+		if capture.Status == 0 {
+			capture.Status = http.StatusOK
+		}
+
+		// Only successful responses are cached, errors are retried on the next request
+		if capture.Status < 200 || capture.Status >= 300 {
+			w.WriteHeader(capture.Status)
+			w.Write(capture.Body.Bytes())
+			return
+		}
+
+		entry = &cacheEntry{
+			Status: capture.Status,
+			Header: w.Header().Clone(),
+			Body:   capture.Body.Bytes(),
+			ETag:   etag(capture.Body.Bytes()),
+			Path:   normalizePath(r.URL.Path),
+			Words:  strings.Fields(normalizeQueryValue("nome", r.URL.Query().Get("nome"))),
+		}
+		for name := range entry.Header {
+			if isUncachedHeader(name) {
+				entry.Header.Del(name)
+			}
+		}
+		cache.Add(cacheKey, entry)
+		w.Header().Set("X-Cache", "MISS")
+		writeCacheEntry(w, r, entry)
+	})
+}
+
+func writeCacheEntry(w http.ResponseWriter, r *http.Request, entry *cacheEntry) {
+	for name, values := range entry.Header {
+		w.Header()[name] = values
+	}
+	w.Header().Set("ETag", entry.ETag)
+	if etagMatches(r.Header.Get("If-None-Match"), entry.ETag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.WriteHeader(entry.Status)
+	w.Write(entry.Body)
+}
+
+// CacheKey is the normalized path followed by the query parameters sorted by name, so
+// "/pessoa/?nome=João%20%20Silva" and "/pessoa?nome=joao+silva" share an entry.
+func CacheKey(u *url.URL) string {
+	query := u.Query()
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	normalized := url.Values{}
+	for _, name := range names {
+		for _, value := range query[name] {
+			normalized.Add(name, normalizeQueryValue(name, value))
+		}
+	}
+	return normalizePath(u.Path) + "?" + normalized.Encode()
+}
+
+func normalizePath(p string) string {
+	p = path.Clean("/" + p)
+	return strings.ToLower(p)
+}
+
+// normalizeQueryValue folds the searched name the same way the search backend does,
+// case and accent insensitive.
+func normalizeQueryValue(name string, value string) string {
+	value = strings.TrimSpace(utils.RemoveExtraSpaces(value))
+	if name == "nome" {
+		value = strings.ToLower(utils.RemoveAccents(value))
+	}
+	return value
+}
+
+func isUncachedHeader(name string) bool {
+	name = http.CanonicalHeaderKey(name)
+	if strings.HasPrefix(name, "Ratelimit-") || strings.HasPrefix(name, "X-Cache") {
+		return true
+	}
+	for _, header := range uncachedHeaders {
+		if name == header {
+			return true
+		}
+	}
+	return false
+}
+
+func etag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+func etagMatches(ifNoneMatch string, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// PurgeCache drops every cached response and returns how many there were.
+func PurgeCache() int {
+	n := cache.Len()
+	cache.Purge()
+	return n
+}
+
+// PurgeCacheEverywhere drops every cached response and marks the cache as purged, so
+// that the other instances drop theirs within CachePurgeCheckEvery. It returns how many
+// responses this instance had.
+func PurgeCacheEverywhere(ctx context.Context) (int, error) {
+	purged := PurgeCache()
+	return purged, repository.MarkCachePurged(ctx, time.Now())
+}
+
+// WatchCachePurges drops the cached responses whenever the cache is marked as purged,
+// looking for the mark every interval until ctx is done.
+func WatchCachePurges(ctx context.Context, every time.Duration) {
+	var seen time.Time
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for {
+		purgedAt, err := repository.FetchCachePurgedAt(ctx)
+		if err != nil {
+			slog.WarnContext(ctx, "Error looking for cache purges", "error", err)
+		} else if purgedAt.After(seen) {
+			// The first mark found may be older than the cache, dropping it then is harmless
+			if purged := PurgeCache(); purged > 0 {
+				slog.InfoContext(ctx, "Cache purged as marked", "purged", purged, "purged_at", purgedAt)
+			}
+			seen = purgedAt
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeCacheFor drops the responses that may have changed because of records with the
+// given names: the searches where a searched word starts a word of one of the names,
+// and every response that is not a search, like the counts and the most recent record.
+// Searches that only match through typo tolerance are left to expire.
+func PurgeCacheFor(nomes []string) int {
+	affected := map[string]bool{}
+	for _, nome := range nomes {
+		for _, word := range strings.Fields(normalizeQueryValue("nome", nome)) {
+			affected[word] = true
+		}
+	}
+
+	purged := 0
+	for _, key := range cache.Keys() {
+		entry, ok := cache.Peek(key)
+		if !ok {
+			continue
+		}
+		if !searchPaths[entry.Path] || searchAffected(entry.Words, affected) {
+			if cache.Remove(key) {
+				purged++
+			}
+		}
+	}
+	return purged
+}
+
+func searchAffected(searched []string, affected map[string]bool) bool {
+	for _, searchedWord := range searched {
+		for word := range affected {
+			if strings.HasPrefix(word, searchedWord) {
+				return true
+			}
+		}
+	}
+	return false
+}
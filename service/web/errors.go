@@ -0,0 +1,168 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"refugio/objects"
+	"runtime/debug"
+)
+
+// Error codes returned to clients, stable so the frontend can branch on them.
+const (
+	CodeInvalidRequest     = "invalid_request"
+	CodeForbidden          = "forbidden"
+	CodeNotFound           = "not_found"
+	CodeMethodNotAllowed   = "method_not_allowed"
+	CodeConflict           = "conflict"
+	CodeRateLimited        = "rate_limited"
+	CodeSearchUnavailable  = "search_unavailable"
+	CodeServiceUnavailable = "service_unavailable"
+	CodeInternal           = "internal_error"
+)
+
+// APIError is an error that can be shown to the API clients. The message is
+// meant for users, the wrapped error is only logged.
+type APIError struct {
+	Status    int    `json:"-"`
+	Code      string `json:"code" doc:"Código estável do erro, como invalid_request ou rate_limited"`
+	Message   string `json:"message" doc:"Mensagem que pode ser mostrada ao usuário"`
+	RequestId string `json:"request_id,omitempty" doc:"Id da requisição, o mesmo do cabeçalho X-Request-Id"`
+	Err       error  `json:"-"`
+}
+
+// ErrorResponse is the body of every error response.
+type ErrorResponse struct {
+	Error APIError `json:"error"`
+}
+
+func (e *APIError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Code, e.Err)
+	}
+	return e.Code + ": " + e.Message
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+func NewError(status int, code string, message string) *APIError {
+	return &APIError{Status: status, Code: code, Message: message}
+}
+
+func InvalidRequest(message string) *APIError {
+	return NewError(http.StatusBadRequest, CodeInvalidRequest, message)
+}
+
+func Forbidden() *APIError {
+	return NewError(http.StatusForbidden, CodeForbidden, "Chave de API ausente, inválida ou sem permissão para este recurso.")
+}
+
+func SearchUnavailable(err error) *APIError {
+	apiErr := NewError(http.StatusServiceUnavailable, CodeSearchUnavailable, "A busca está indisponível no momento. Tente novamente em instantes.")
+	apiErr.Err = err
+	return apiErr
+}
+
+func ServiceUnavailable(err error) *APIError {
+	apiErr := NewError(http.StatusServiceUnavailable, CodeServiceUnavailable, "Serviço indisponível no momento. Tente novamente em instantes.")
+	apiErr.Err = err
+	return apiErr
+}
+
+func Internal(err error) *APIError {
+	apiErr := NewError(http.StatusInternalServerError, CodeInternal, "Erro interno. Tente novamente mais tarde.")
+	apiErr.Err = err
+	return apiErr
+}
+
+// WriteError answers the request with the error as JSON. Errors that are not
+// an APIError are reported as internal errors, without their details.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		apiErr = Internal(err)
+	}
+	response := *apiErr
+	response.RequestId = RequestId(r.Context())
+
+	if apiErr.Err != nil {
+		level := slog.LevelWarn
+		if apiErr.Status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		slog.Log(r.Context(), level, "Request failed", "code", apiErr.Code, "error", apiErr.Err)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(apiErr.Status)
+	json.NewEncoder(w).Encode(ErrorResponse{Error: response})
+}
+
+// RequestId returns the id given to the request by BaseRequestMiddleware.
+func RequestId(ctx context.Context) string {
+	if accessLog, ok := ctx.Value(ACCESS_LOG_CONTEXT_KEY).(*objects.AccessLog); ok {
+		return accessLog.RequestId
+	}
+	return ""
+}
+
+// NotFound answers requests that did not match any route.
+func NotFound(w http.ResponseWriter, r *http.Request) {
+	WriteError(w, r, NewError(http.StatusNotFound, CodeNotFound, "Recurso não encontrado."))
+}
+
+// MethodNotAllowed answers requests that matched a route but not its methods.
+func MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	WriteError(w, r, NewError(http.StatusMethodNotAllowed, CodeMethodNotAllowed, "Método não permitido para este recurso."))
+}
+
+// wroteRecorder remembers whether the handlers already started the response.
+type wroteRecorder struct {
+	http.ResponseWriter
+	wrote bool
+}
+
+func (w *wroteRecorder) WriteHeader(status int) {
+	w.wrote = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *wroteRecorder) Write(data []byte) (int, error) {
+	w.wrote = true
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *wroteRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// RecoverMiddleware turns panics of the handlers into 500 responses, so that a
+// bad request cannot take the process down.
+func RecoverMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		recorder := &wroteRecorder{ResponseWriter: w}
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			// net/http uses this panic to abort the response on purpose
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+			slog.ErrorContext(r.Context(), "Recovered from panic", "panic", fmt.Sprint(recovered), "stack", string(debug.Stack()))
+			if recorder.wrote {
+				// Too late for an error response, dropping the connection instead
+				panic(http.ErrAbortHandler)
+			}
+			WriteError(w, r, Internal(nil))
+		}()
+		next.ServeHTTP(recorder, r)
+	})
+}
@@ -0,0 +1,12 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+func AuthMe(w http.ResponseWriter, r *http.Request) {
+	response := map[string]string{"status": "success", "message": "Authenticated successfully"}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
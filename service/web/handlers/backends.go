@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"refugio/metrics"
+	"refugio/objects"
+	"refugio/repository"
+	"refugio/takedown"
+	"refugio/tracing"
+	"time"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+)
+
+// The backends the handlers of the versioned API call, replaced with stubs by the tests
+var (
+	searchNomes     = searchAlgolia
+	fetchPessoas    = repository.FetchPessoaFromFirestore
+	fetchCounters   = repository.FetchPessoaCounters
+	fetchMostRecent = repository.FetchMostRecent
+	fetchStatsDays  = repository.FetchStatsDays
+	fetchSources    = repository.FetchSourcesFromFirestore
+	requestTakedown = takedown.Request
+)
+
+// searchAlgolia returns the records of the index matching nome, best matches first.
+func searchAlgolia(ctx context.Context, nome string) ([]objects.PessoaSearchResult, error) {
+	client := search.NewClient(os.Getenv("ALGOLIA_CLIENT"), os.Getenv("ALGOLIA_API_KEY"))
+	index := client.InitIndex(os.Getenv("ALGOLIA_INDEX"))
+
+	searchStart := time.Now()
+	_, span := tracing.Start(ctx, "algolia.Search")
+	results, err := index.Search(nome)
+	tracing.End(span, err)
+	metrics.ObserveBackend("algolia", "Search", searchStart)
+	if err != nil {
+		return nil, err
+	}
+
+	var pessoasSearch []objects.PessoaSearchResult
+	if err := results.UnmarshalHits(&pessoasSearch); err != nil {
+		return nil, fmt.Errorf("reading search results: %w", err)
+	}
+	return pessoasSearch, nil
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"refugio/web"
+)
+
+type purgeCacheResult struct {
+	Purged int `json:"purged"`
+}
+
+// PurgeCache drops the cached responses of this instance affected by the given nome
+// parameters, or the whole cache of every instance when there are none.
+func PurgeCache(w http.ResponseWriter, r *http.Request) {
+	var result purgeCacheResult
+	if nomes := r.URL.Query()["nome"]; len(nomes) > 0 {
+		result.Purged = web.PurgeCacheFor(nomes)
+	} else {
+		purged, err := web.PurgeCacheEverywhere(r.Context())
+		if err != nil {
+			web.WriteError(w, r, web.Internal(fmt.Errorf("marking cache purge: %w", err)))
+			return
+		}
+		result.Purged = purged
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"refugio/api"
+	"refugio/objects"
+	"refugio/takedown"
+	"refugio/web"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+var errBackend = errors.New("backend unavailable")
+
+// conformanceCase is a request to a documented route, made with the backends stubbed
+// as stub leaves them.
+type conformanceCase struct {
+	name   string
+	method string
+	path   string
+	query  string
+	body   string
+	stub   func()
+	// scopes, when set, are the scopes of the key the request is made with
+	scopes []string
+	status int
+}
+
+// newTestRouter serves the versioned routes as main does, without the auth, rate limit
+// and cache middlewares, which do not change the body of the responses.
+func newTestRouter() *mux.Router {
+	router := mux.NewRouter()
+	router.Use(web.BaseRequestMiddleware)
+	router.HandleFunc(api.Version+"/pessoa", GetPessoaV1).Methods(http.MethodGet)
+	router.HandleFunc(api.Version+"/pessoa/count", GetRecordCount).Methods(http.MethodGet)
+	router.HandleFunc(api.Version+"/pessoa/most_recent", GetMostRecent).Methods(http.MethodGet)
+	router.HandleFunc(api.Version+"/stats", GetStats).Methods(http.MethodGet)
+	router.HandleFunc(api.Version+"/sources", GetSourcesV1).Methods(http.MethodGet)
+	router.HandleFunc(api.Version+"/takedowns", CreateTakedown).Methods(http.MethodPost)
+	return router
+}
+
+// stubBackends answers every backend with sample data, restoring them when the test ends.
+func stubBackends(t *testing.T) {
+	saved := []func(){
+		restore(&searchNomes), restore(&fetchPessoas), restore(&fetchCounters), restore(&fetchMostRecent),
+		restore(&fetchStatsDays), restore(&fetchSources), restore(&requestTakedown),
+	}
+	t.Cleanup(func() {
+		for _, r := range saved {
+			r()
+		}
+	})
+
+	now := time.Now().UTC().Truncate(time.Second)
+	sheetId := "1--z2fbczdFT4RSoji7jXc2jDDU5HqWgAU93NuROBQ78"
+	url := ""
+	searchNomes = func(ctx context.Context, nome string) ([]objects.PessoaSearchResult, error) {
+		return []objects.PessoaSearchResult{{ObjectID: "mariadasilvafapa", Nome: "Maria da Silva"}, {ObjectID: "joaomariafapa", Nome: "João Maria"}}, nil
+	}
+	fetchPessoas = func(ctx context.Context, docIDs []string) ([]*objects.PessoaResult, error) {
+		return []*objects.PessoaResult{
+			{Pessoa: &objects.Pessoa{Nome: "Maria Da Silva", Abrigo: "FAPA", Idade: "34"}, SheetId: &sheetId, URL: &url, Cidade: "Porto Alegre", Timestamp: now, LastSeen: now},
+			{Pessoa: &objects.Pessoa{Nome: "João Maria", Abrigo: "FAPA"}, SheetId: &sheetId, URL: &url, Timestamp: now, Departed: true},
+		}, nil
+	}
+	fetchCounters = func(ctx context.Context) (*objects.PessoaCounters, error) {
+		return &objects.PessoaCounters{Total: 2, Departed: 1, BySource: map[string]int{sheetId: 2}, ByAbrigo: map[string]int{"FAPA": 2}, UpdatedAt: now}, nil
+	}
+	fetchMostRecent = func(ctx context.Context, key string) (*time.Time, error) {
+		return &now, nil
+	}
+	fetchStatsDays = func(ctx context.Context, from string, to string) ([]*objects.StatsDay, error) {
+		return []*objects.StatsDay{{
+			Date:       "2024-05-12",
+			Total:      2,
+			Groups:     []objects.StatsGroup{{SheetId: sheetId, Abrigo: "FAPA", Cidade: "Porto Alegre", Total: 2, Departed: 1}},
+			ComputedAt: now,
+		}}, nil
+	}
+	fetchSources = func(ctx context.Context) ([]*objects.Source, error) {
+		failedAt := now.Add(-time.Hour)
+		return []*objects.Source{
+			{Nome: "Abrigados - FAPA", SheetId: sheetId, URL: "https://docs.google.com/spreadsheets/d/" + sheetId, Sheets: []string{"Planilha1"}, Configured: true,
+				Organizacao: "FAPA", Cidade: "Porto Alegre", Regiao: "Região Metropolitana de Porto Alegre", ModifiedAt: &failedAt, ScrapedAt: &now,
+				Health: objects.SourceHealth{LastSuccessAt: &now}},
+			{Nome: "Listada no Planilhão", SheetId: "abc", URL: "https://example.com/lista"},
+			{Nome: "Desativada", SheetId: "def", Configured: true,
+				Health: objects.SourceHealth{Failures: 5, LastError: "reading Sheet1!A1:ZZ: 403", LastErrorAt: &failedAt, Disabled: true, DisabledAt: &failedAt}},
+		}, nil
+	}
+	requestTakedown = func(ctx context.Context, key string, kind string, correction *objects.Correction, reason string, contact string) (*objects.Takedown, error) {
+		return &objects.Takedown{Id: "td1", Key: key, Kind: kind, Correction: correction, Reason: reason, Contact: contact, Status: objects.TakedownPending, CreatedAt: now}, nil
+	}
+}
+
+func restore[T any](backend *T) func() {
+	saved := *backend
+	return func() { *backend = saved }
+}
+
+func TestConformance(t *testing.T) {
+	takedownBody := `{"id":"mariadasilvafapa","kind":"remove","reason":"Pedido da própria pessoa","contact":"maria@example.com"}`
+	cases := []conformanceCase{
+		{name: "search", method: http.MethodGet, path: "/pessoa", query: "nome=maria", status: http.StatusOK},
+		{name: "search with hidden", method: http.MethodGet, path: "/pessoa", query: "nome=maria&include_hidden=true", scopes: []string{objects.ScopeAdmin}, status: http.StatusOK, stub: func() {
+			fetchPessoas = func(ctx context.Context, docIDs []string) ([]*objects.PessoaResult, error) {
+				return []*objects.PessoaResult{{Pessoa: &objects.Pessoa{Nome: "Maria Da Silva", Abrigo: "FAPA"}, Timestamp: time.Now().AddDate(0, 0, -60)}}, nil
+			}
+		}},
+		{name: "search with hidden without admin", method: http.MethodGet, path: "/pessoa", query: "nome=maria&include_hidden=true", scopes: []string{objects.ScopeSearch}, status: http.StatusForbidden},
+		{name: "search without nome", method: http.MethodGet, path: "/pessoa", status: http.StatusBadRequest},
+		{name: "search with short nome", method: http.MethodGet, path: "/pessoa", query: "nome=ma", status: http.StatusBadRequest},
+		{name: "search with bad include_hidden", method: http.MethodGet, path: "/pessoa", query: "nome=maria&include_hidden=talvez", status: http.StatusBadRequest},
+		{name: "search unavailable", method: http.MethodGet, path: "/pessoa", query: "nome=maria", status: http.StatusServiceUnavailable, stub: func() {
+			searchNomes = func(ctx context.Context, nome string) ([]objects.PessoaSearchResult, error) { return nil, errBackend }
+		}},
+		{name: "search without records", method: http.MethodGet, path: "/pessoa", query: "nome=maria", status: http.StatusOK, stub: func() {
+			fetchPessoas = func(ctx context.Context, docIDs []string) ([]*objects.PessoaResult, error) { return nil, nil }
+		}},
+		{name: "search failing to fetch", method: http.MethodGet, path: "/pessoa", query: "nome=maria", status: http.StatusInternalServerError, stub: func() {
+			fetchPessoas = func(ctx context.Context, docIDs []string) ([]*objects.PessoaResult, error) { return nil, errBackend }
+		}},
+		{name: "count", method: http.MethodGet, path: "/pessoa/count", status: http.StatusOK},
+		{name: "count before rebuild", method: http.MethodGet, path: "/pessoa/count", status: http.StatusOK, stub: func() {
+			fetchCounters = func(ctx context.Context) (*objects.PessoaCounters, error) { return nil, nil }
+		}},
+		{name: "count failing", method: http.MethodGet, path: "/pessoa/count", status: http.StatusInternalServerError, stub: func() {
+			fetchCounters = func(ctx context.Context) (*objects.PessoaCounters, error) { return nil, errBackend }
+		}},
+		{name: "most recent", method: http.MethodGet, path: "/pessoa/most_recent", status: http.StatusOK},
+		{name: "most recent failing", method: http.MethodGet, path: "/pessoa/most_recent", status: http.StatusInternalServerError, stub: func() {
+			fetchMostRecent = func(ctx context.Context, key string) (*time.Time, error) { return nil, errBackend }
+		}},
+		{name: "stats", method: http.MethodGet, path: "/stats", status: http.StatusOK},
+		{name: "stats grouped", method: http.MethodGet, path: "/stats", query: "from=2024-05-01&to=2024-05-31&group_by=source,abrigo,cidade", status: http.StatusOK},
+		{name: "stats with bad date", method: http.MethodGet, path: "/stats", query: "from=12/05/2024", status: http.StatusBadRequest},
+		{name: "stats with bad group", method: http.MethodGet, path: "/stats", query: "group_by=nome", status: http.StatusBadRequest},
+		{name: "stats failing", method: http.MethodGet, path: "/stats", status: http.StatusInternalServerError, stub: func() {
+			fetchStatsDays = func(ctx context.Context, from string, to string) ([]*objects.StatsDay, error) { return nil, errBackend }
+		}},
+		{name: "sources", method: http.MethodGet, path: "/sources", status: http.StatusOK},
+		{name: "sources failing", method: http.MethodGet, path: "/sources", status: http.StatusInternalServerError, stub: func() {
+			fetchSources = func(ctx context.Context) ([]*objects.Source, error) { return nil, errBackend }
+		}},
+		{name: "takedown", method: http.MethodPost, path: "/takedowns", body: takedownBody, status: http.StatusCreated},
+		{name: "takedown correct", method: http.MethodPost, path: "/takedowns", status: http.StatusCreated,
+			body: `{"id":"mariadasilvafapa","kind":"correct","correction":{"idade":"43","observacao":""},"reason":"A idade está errada"}`},
+		{name: "takedown with unknown field", method: http.MethodPost, path: "/takedowns", body: `{"id":"x","nome":"Maria"}`, status: http.StatusBadRequest},
+		{name: "takedown invalid", method: http.MethodPost, path: "/takedowns", body: takedownBody, status: http.StatusBadRequest, stub: func() {
+			requestTakedown = func(ctx context.Context, key string, kind string, correction *objects.Correction, reason string, contact string) (*objects.Takedown, error) {
+				return nil, fmt.Errorf("%w: o kind deve ser remove, redact ou correct", takedown.ErrInvalidRequest)
+			}
+		}},
+		{name: "takedown of unknown record", method: http.MethodPost, path: "/takedowns", body: takedownBody, status: http.StatusNotFound, stub: func() {
+			requestTakedown = func(ctx context.Context, key string, kind string, correction *objects.Correction, reason string, contact string) (*objects.Takedown, error) {
+				return nil, takedown.ErrUnknownRecord
+			}
+		}},
+		{name: "takedown failing", method: http.MethodPost, path: "/takedowns", body: takedownBody, status: http.StatusInternalServerError, stub: func() {
+			requestTakedown = func(ctx context.Context, key string, kind string, correction *objects.Correction, reason string, contact string) (*objects.Takedown, error) {
+				return nil, errBackend
+			}
+		}},
+	}
+
+	spec := api.Spec()
+	router := newTestRouter()
+	covered := map[string]bool{}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			stubBackends(t)
+			if c.stub != nil {
+				c.stub()
+			}
+
+			path := api.Version + c.path
+			op := spec.Paths[path][strings.ToLower(c.method)]
+			if op == nil {
+				t.Fatalf("%s %s is not documented", c.method, path)
+			}
+			covered[c.method+" "+path] = true
+
+			target := path
+			if c.query != "" {
+				target += "?" + c.query
+			}
+			var body io.Reader
+			if c.body != "" {
+				body = strings.NewReader(c.body)
+			}
+			request := httptest.NewRequest(c.method, target, body)
+			if c.scopes != nil {
+				request = request.WithContext(web.WithKey(request.Context(), &objects.ApiKey{Id: "test", Scopes: c.scopes, Enabled: true}))
+			}
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			if recorder.Code != c.status {
+				t.Errorf("status %d, want %d: %s", recorder.Code, c.status, recorder.Body.String())
+			}
+			for _, problem := range spec.ValidateResponse(op, recorder.Code, recorder.Header().Get("Content-Type"), recorder.Body.Bytes()) {
+				t.Error(problem)
+			}
+		})
+	}
+
+	for path, operations := range spec.Paths {
+		for method := range operations {
+			if name := strings.ToUpper(method) + " " + path; !covered[name] {
+				t.Errorf("%s is documented but not covered", name)
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"refugio/export"
+	"refugio/objects"
+	"refugio/web"
+	"time"
+)
+
+// Each page must reach the client within this time, the server write timeout would
+// otherwise cut long exports
+const exportPageTimeout = time.Minute
+
+// countingWriter tells whether the export already started the response.
+type countingWriter struct {
+	http.ResponseWriter
+	written int64
+}
+
+func (w *countingWriter) Write(data []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(data)
+	w.written += int64(n)
+	return n, err
+}
+
+func GetExport(w http.ResponseWriter, r *http.Request) {
+	values := r.URL.Query()
+	format, err := export.ParseFormat(values.Get("format"))
+	var filter objects.PessoaFilter
+	if err == nil {
+		filter, err = export.ParseFilter(values)
+	}
+	if errors.Is(err, export.ErrInvalidFilter) {
+		web.WriteError(w, r, web.InvalidRequest(err.Error()))
+		return
+	}
+
+	controller := http.NewResponseController(w)
+	extendDeadline := func() {
+		if err := controller.SetWriteDeadline(time.Now().Add(exportPageTimeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			slog.WarnContext(r.Context(), "Error extending the export deadline", "error", err)
+		}
+	}
+	extendDeadline()
+
+	w.Header().Set("Content-Type", export.ContentType(format))
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="pessoas-%s.%s"`, time.Now().Format("20060102-150405"), format))
+	out := &countingWriter{ResponseWriter: w}
+	written, err := export.Export(r.Context(), out, format, filter, func(int) {
+		controller.Flush()
+		extendDeadline()
+	})
+	web.SetResultCount(r.Context(), written)
+	if err == nil {
+		return
+	}
+	if out.written == 0 {
+		w.Header().Del("Content-Disposition")
+		web.WriteError(w, r, web.Internal(fmt.Errorf("exporting records: %w", err)))
+		return
+	}
+	// The status was sent with the first page, aborting tells the client the file is incomplete
+	slog.ErrorContext(r.Context(), "Export failed after it started", "written", written, "error", err)
+	panic(http.ErrAbortHandler)
+}
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"refugio/api"
+	"refugio/repository"
+	"refugio/web"
+
+	"github.com/gorilla/mux"
+)
+
+func GetPessoaHistory(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	revisions, err := repository.FetchRevisions(r.Context(), id)
+	if err != nil {
+		web.WriteError(w, r, web.Internal(fmt.Errorf("fetching revisions: %w", err)))
+		return
+	}
+	if len(revisions) == 0 {
+		web.WriteError(w, r, web.NewError(http.StatusNotFound, web.CodeNotFound, "Nenhuma alteração registrada para este id."))
+		return
+	}
+	web.SetResultCount(r.Context(), len(revisions))
+	writeJSON(w, r, api.NewHistory(id, revisions))
+}
@@ -0,0 +1,10 @@
+package handlers
+
+import (
+	"net/http"
+	"refugio/api"
+)
+
+func GetOpenAPI(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, r, api.Spec())
+}
@@ -1,45 +1,88 @@
 package handlers
 
 import (
-	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
-	"os"
+	"refugio/api"
 	"refugio/objects"
 	"refugio/repository"
-	"refugio/sheetscraper"
+	"refugio/retention"
 	"refugio/utils"
-	"refugio/utils/cuckoo"
+	"refugio/web"
+	"regexp"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
-
-	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"time"
 )
 
 const MaxResults = 100
 
+var validNome = regexp.MustCompile(api.NomePattern)
+
+// legacyPessoa is a record as /pessoa has always returned it, the fields added since
+// are only in /v1/pessoa.
+type legacyPessoa struct {
+	Abrigo     string
+	Nome       string
+	Idade      string
+	Observacao string
+	SheetId    *string
+	URL        *string
+	Timestamp  time.Time
+}
+
 func GetPessoa(w http.ResponseWriter, r *http.Request) {
-	nome := r.URL.Query().Get("nome")
-	if nome == "" {
-		http.Error(w, "nome é obrigatório", http.StatusBadRequest)
+	pessoas, err := searchPessoas(r)
+	if err != nil {
+		web.WriteError(w, r, err)
 		return
 	}
+	legacy := make([]legacyPessoa, 0, len(pessoas))
+	for _, p := range pessoas {
+		legacy = append(legacy, legacyPessoa{Abrigo: p.Abrigo, Nome: p.Nome, Idade: p.Idade, Observacao: p.Observacao, SheetId: p.SheetId, URL: p.URL, Timestamp: p.Timestamp})
+	}
+	web.SetResultCount(r.Context(), len(pessoas))
+	writeJSON(w, r, legacy)
+}
 
-	client := search.NewClient(os.Getenv("ALGOLIA_CLIENT"), os.Getenv("ALGOLIA_API_KEY"))
-	index := client.InitIndex(os.Getenv("ALGOLIA_INDEX"))
-
-	results, err := index.Search(nome)
+func GetPessoaV1(w http.ResponseWriter, r *http.Request) {
+	pessoas, err := searchPessoas(r)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("failed request to algolia API %v\n", err), http.StatusInternalServerError)
-		panic(err)
+		web.WriteError(w, r, err)
+		return
+	}
+	web.SetResultCount(r.Context(), len(pessoas))
+	writeJSON(w, r, api.NewPessoas(pessoas))
+}
+
+// searchPessoas finds the records matching the nome of the request, the ones
+// starting with it first, then the most recent first.
+func searchPessoas(r *http.Request) ([]*objects.PessoaResult, error) {
+	nome := r.URL.Query().Get("nome")
+	if nome == "" {
+		return nil, web.InvalidRequest("O parâmetro nome é obrigatório.")
+	}
+	if !validNome.MatchString(nome) {
+		return nil, web.InvalidRequest("O nome deve ter pelo menos 3 caracteres, apenas letras, números e espaços.")
+	}
+	includeHidden := false
+	if value := r.URL.Query().Get("include_hidden"); value != "" {
+		var err error
+		if includeHidden, err = strconv.ParseBool(value); err != nil {
+			return nil, web.InvalidRequest("O parâmetro include_hidden deve ser true ou false.")
+		}
+	}
+	// The retention policy only gives way to admins
+	if includeHidden && !web.HasScope(r, objects.ScopeAdmin) {
+		return nil, web.Forbidden()
 	}
 
-	var pessoasSearch []objects.PessoaSearchResult
-	err = results.UnmarshalHits(&pessoasSearch)
+	pessoasSearch, err := searchNomes(r.Context(), nome)
 	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+		return nil, web.SearchUnavailable(err)
 	}
 
 	docIDs := make([]string, 0, len(pessoasSearch))
@@ -53,65 +96,54 @@ func GetPessoa(w http.ResponseWriter, r *http.Request) {
 			docIDs = append(docIDs, result.ObjectID)
 		}
 	}
-	if len(docIDs) > MaxResults {
-		docIDs = docIDs[:MaxResults]
-	}
-
-	pessoas, err := repository.FetchPessoaFromFirestore(docIDs)
+	// Records long gone from their source are kept out unless asked for, until they are
+	// purged. They are dropped before the results are cut to MaxResults, fetching the next
+	// matches while there is room, so that they do not take the place of visible ones.
+	policy, err := retention.CurrentPolicy()
 	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+		return nil, web.Internal(err)
+	}
+	now := time.Now()
+	var pessoas []*objects.PessoaResult
+	for start := 0; start < len(docIDs) && len(pessoas) < MaxResults; start += MaxResults {
+		page, err := fetchPessoas(r.Context(), docIDs[start:min(start+MaxResults, len(docIDs))])
+		if err != nil {
+			return nil, web.Internal(fmt.Errorf("fetching people: %w", err))
+		}
+		if !includeHidden {
+			page = slices.DeleteFunc(page, func(pessoa *objects.PessoaResult) bool {
+				return policy.IsHidden(pessoa, now)
+			})
+		}
+		pessoas = append(pessoas, page...)
+	}
+	if len(pessoas) > MaxResults {
+		pessoas = pessoas[:MaxResults]
 	}
 
 	sort.SliceStable(pessoas, func(i, j int) bool {
 		return pessoas[i].Timestamp.After(pessoas[j].Timestamp)
 	})
-
-	jsonBytes, err := json.Marshal(pessoas)
-
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("Content-Type", "application/json")
-	w.Write(jsonBytes)
+	return pessoas, nil
 }
 
 func GetRecordCount(w http.ResponseWriter, r *http.Request) {
-	filter, err := cuckoo.GetCuckooFilter(sheetscraper.Pessoa)
+	counters, err := fetchCounters(r.Context())
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error getting filter: %v\n", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		web.WriteError(w, r, web.Internal(fmt.Errorf("fetching counters: %w", err)))
 		return
 	}
-
-	var result objects.PessoaCountResult
-	result.Total = int(filter.Count())
-	jsonBytes, err := json.Marshal(result)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error marshalling filter count result JSON: %v\n", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
-		return
+	if counters == nil {
+		slog.WarnContext(r.Context(), "No record counters stored, run the counters rebuild command")
 	}
-
-	w.Write(jsonBytes)
+	writeJSON(w, r, api.NewCount(counters))
 }
 
 func GetMostRecent(w http.ResponseWriter, r *http.Request) {
-	most_recent, err := repository.FetchMostRecent(repository.PessoasAbrigos)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error fetching most recent: %v\n", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
-		return
-	}
-	var result objects.PessoaMostRecentResult
-	result.Timestamp = most_recent
-	jsonBytes, err := json.Marshal(result)
+	most_recent, err := fetchMostRecent(r.Context(), repository.PessoasAbrigos)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error marshalling JSON: %v\n", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		web.WriteError(w, r, web.Internal(fmt.Errorf("fetching most recent: %w", err)))
 		return
 	}
-	w.Write(jsonBytes)
+	writeJSON(w, r, api.MostRecent{Timestamp: most_recent})
 }
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"refugio/objects"
+	"refugio/web"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSearchDropsHiddenBeforeTruncating checks that hidden records do not take the
+// place of visible matches ranked after them.
+func TestSearchDropsHiddenBeforeTruncating(t *testing.T) {
+	stubBackends(t)
+	hits := make([]objects.PessoaSearchResult, 0, 2*MaxResults)
+	for i := range 2 * MaxResults {
+		hits = append(hits, objects.PessoaSearchResult{ObjectID: fmt.Sprintf("maria%03d", i), Nome: "Ana Maria"})
+	}
+	searchNomes = func(ctx context.Context, nome string) ([]objects.PessoaSearchResult, error) {
+		return hits, nil
+	}
+	// The best half of the matches is long gone from its source
+	gone := time.Now().AddDate(-1, 0, 0)
+	fetched := 0
+	fetchPessoas = func(ctx context.Context, docIDs []string) ([]*objects.PessoaResult, error) {
+		fetched += len(docIDs)
+		pessoas := make([]*objects.PessoaResult, 0, len(docIDs))
+		for _, id := range docIDs {
+			pessoa := &objects.PessoaResult{Pessoa: &objects.Pessoa{Nome: id, Abrigo: "FAPA"}, Timestamp: time.Now(), LastSeen: time.Now()}
+			if id < fmt.Sprintf("maria%03d", MaxResults) {
+				pessoa.Timestamp, pessoa.LastSeen = gone, gone
+			}
+			pessoas = append(pessoas, pessoa)
+		}
+		return pessoas, nil
+	}
+
+	pessoas, err := searchPessoas(httptest.NewRequest("GET", "/v1/pessoa?nome=maria", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pessoas) != MaxResults {
+		t.Errorf("%d results, want %d", len(pessoas), MaxResults)
+	}
+	for _, pessoa := range pessoas {
+		if pessoa.Timestamp.Equal(gone) {
+			t.Errorf("hidden record %s returned", pessoa.Nome)
+		}
+	}
+	if fetched != 2*MaxResults {
+		t.Errorf("%d records fetched, want %d", fetched, 2*MaxResults)
+	}
+
+	// Asking for the hidden ones fills the results without fetching more
+	fetched = 0
+	request := httptest.NewRequest("GET", "/v1/pessoa?nome=maria&include_hidden=true", nil)
+	request = request.WithContext(web.WithKey(request.Context(), &objects.ApiKey{Id: "admin", Scopes: []string{objects.ScopeAdmin}, Enabled: true}))
+	pessoas, err = searchPessoas(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pessoas) != MaxResults || fetched != MaxResults {
+		t.Errorf("%d results from %d fetched, want %d from %d", len(pessoas), fetched, MaxResults, MaxResults)
+	}
+	if !strings.HasPrefix(pessoas[len(pessoas)-1].Nome, "maria0") {
+		t.Errorf("last result %s, want one of the best matches", pessoas[len(pessoas)-1].Nome)
+	}
+}
+
+// TestLegacyPessoaFields checks that /pessoa keeps the fields it always had.
+func TestLegacyPessoaFields(t *testing.T) {
+	stubBackends(t)
+	recorder := httptest.NewRecorder()
+	GetPessoa(recorder, httptest.NewRequest("GET", "/pessoa?nome=maria", nil))
+
+	var pessoas []map[string]any
+	if err := json.Unmarshal(recorder.Body.Bytes(), &pessoas); err != nil {
+		t.Fatalf("reading %s: %v", recorder.Body.String(), err)
+	}
+	if len(pessoas) == 0 {
+		t.Fatal("no results")
+	}
+	want := []string{"Abrigo", "Idade", "Nome", "Observacao", "SheetId", "Timestamp", "URL"}
+	for _, pessoa := range pessoas {
+		fields := make([]string, 0, len(pessoa))
+		for field := range pessoa {
+			fields = append(fields, field)
+		}
+		if slices.Sort(fields); !slices.Equal(fields, want) {
+			t.Errorf("fields %v, want %v", fields, want)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"refugio/objects"
+	"refugio/repository"
+	"refugio/scheduler"
+	"refugio/web"
+)
+
+type scrapeStatusResult struct {
+	LastRun     *objects.ScrapeRun `json:"last_run"`
+	LastSuccess *objects.ScrapeRun `json:"last_success"`
+	Scheduler   *scheduler.Status  `json:"scheduler,omitempty"`
+}
+
+func GetScrapeStatus(w http.ResponseWriter, r *http.Request) {
+	var result scrapeStatusResult
+	var err error
+
+	result.LastRun, err = repository.FetchScrapeRun(r.Context(), repository.LastScrapeRun)
+	if err != nil {
+		web.WriteError(w, r, web.Internal(fmt.Errorf("fetching last scrape run: %w", err)))
+		return
+	}
+	result.LastSuccess, err = repository.FetchScrapeRun(r.Context(), repository.LastSuccessScrapeRun)
+	if err != nil {
+		web.WriteError(w, r, web.Internal(fmt.Errorf("fetching last successful scrape run: %w", err)))
+		return
+	}
+	if s := scheduler.Active(); s != nil {
+		status := s.Status()
+		result.Scheduler = &status
+	}
+
+	jsonBytes, err := json.Marshal(result)
+	if err != nil {
+		web.WriteError(w, r, web.Internal(err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(jsonBytes)
+}
@@ -1,28 +1,42 @@
 package handlers
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
-	"os"
-	"refugio/repository"
+	"refugio/api"
+	"refugio/web"
 )
 
-func GetSources(w http.ResponseWriter, r *http.Request) {
-	sources, err := repository.FetchSourcesFromFirestore()
+// legacySource is a source as /sources has always returned it, the fields added since
+// are only in /v1/sources.
+type legacySource struct {
+	Nome       string
+	SheetId    string
+	URL        string
+	Observacao string
+	Sheets     []string
+}
 
+func GetSources(w http.ResponseWriter, r *http.Request) {
+	sources, err := fetchSources(r.Context())
 	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		web.WriteError(w, r, web.Internal(fmt.Errorf("fetching sources: %w", err)))
 		return
 	}
+	legacy := make([]legacySource, 0, len(sources))
+	for _, s := range sources {
+		legacy = append(legacy, legacySource{Nome: s.Nome, SheetId: s.SheetId, URL: s.URL, Observacao: s.Observacao, Sheets: s.Sheets})
+	}
+	web.SetResultCount(r.Context(), len(sources))
+	writeJSON(w, r, legacy)
+}
 
-	jsonBytes, err := json.Marshal(sources)
-
+func GetSourcesV1(w http.ResponseWriter, r *http.Request) {
+	sources, err := fetchSources(r.Context())
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		web.WriteError(w, r, web.Internal(fmt.Errorf("fetching sources: %w", err)))
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.Write(jsonBytes)
+	web.SetResultCount(r.Context(), len(sources))
+	writeJSON(w, r, api.NewSources(sources))
 }
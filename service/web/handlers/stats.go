@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"refugio/api"
+	"refugio/stats"
+	"refugio/web"
+)
+
+func GetStats(w http.ResponseWriter, r *http.Request) {
+	query, err := stats.ParseQuery(r.URL.Query())
+	if errors.Is(err, stats.ErrInvalidQuery) {
+		web.WriteError(w, r, web.InvalidRequest(err.Error()))
+		return
+	}
+
+	days, err := fetchStatsDays(r.Context(), query.From, query.To)
+	if err != nil {
+		web.WriteError(w, r, web.Internal(fmt.Errorf("fetching stats: %w", err)))
+		return
+	}
+	result := api.NewStats(query, days)
+	web.SetResultCount(r.Context(), len(result.Series))
+	writeJSON(w, r, result)
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"refugio/api"
+	"refugio/objects"
+	"refugio/repository"
+	"refugio/scheduler"
+	"refugio/takedown"
+	"refugio/web"
+	"slices"
+
+	"github.com/gorilla/mux"
+)
+
+// Takedown requests are small, anything larger is not one
+const maxTakedownBody = 16 << 10
+
+func CreateTakedown(w http.ResponseWriter, r *http.Request) {
+	var request api.TakedownRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxTakedownBody))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&request); err != nil {
+		web.WriteError(w, r, web.InvalidRequest("O corpo deve ser um JSON com id, kind, correction, reason e contact."))
+		return
+	}
+
+	var correction *objects.Correction
+	if request.Correction != nil {
+		correction = &objects.Correction{Idade: request.Correction.Idade, Observacao: request.Correction.Observacao}
+	}
+	created, err := requestTakedown(r.Context(), request.Id, request.Kind, correction, request.Reason, request.Contact)
+	switch {
+	case errors.Is(err, takedown.ErrInvalidRequest):
+		web.WriteError(w, r, web.InvalidRequest(err.Error()))
+		return
+	case errors.Is(err, takedown.ErrUnknownRecord):
+		web.WriteError(w, r, web.NewError(http.StatusNotFound, web.CodeNotFound, "Não há registro com este id."))
+		return
+	case err != nil:
+		web.WriteError(w, r, web.Internal(fmt.Errorf("requesting takedown: %w", err)))
+		return
+	}
+	writeJSONStatus(w, r, http.StatusCreated, api.TakedownCreated{Id: created.Id, Status: created.Status})
+}
+
+func GetTakedowns(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	if status != "" && !slices.Contains([]string{objects.TakedownPending, objects.TakedownApproved, objects.TakedownRejected}, status) {
+		web.WriteError(w, r, web.InvalidRequest("O status deve ser pending, approved ou rejected."))
+		return
+	}
+	takedowns, err := repository.FetchTakedowns(r.Context(), status)
+	if err != nil {
+		web.WriteError(w, r, web.Internal(fmt.Errorf("fetching takedowns: %w", err)))
+		return
+	}
+	web.SetResultCount(r.Context(), len(takedowns))
+	writeJSON(w, r, api.NewTakedowns(takedowns))
+}
+
+type takedownDecision struct {
+	Note string `json:"note"`
+}
+
+func ApproveTakedown(w http.ResponseWriter, r *http.Request) {
+	decideTakedown(w, r, takedown.Approve)
+}
+
+func RejectTakedown(w http.ResponseWriter, r *http.Request) {
+	decideTakedown(w, r, takedown.Reject)
+}
+
+func decideTakedown(w http.ResponseWriter, r *http.Request, decide func(ctx context.Context, id string, note string) (*objects.Takedown, error)) {
+	var decision takedownDecision
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxTakedownBody)).Decode(&decision); err != nil {
+			web.WriteError(w, r, web.InvalidRequest("O corpo deve ser um JSON com note, ou vazio."))
+			return
+		}
+	}
+
+	decided, err := decide(r.Context(), mux.Vars(r)["id"], decision.Note)
+	switch {
+	case errors.Is(err, takedown.ErrNotFound):
+		web.WriteError(w, r, web.NewError(http.StatusNotFound, web.CodeNotFound, "Não há pedido com este id."))
+		return
+	case errors.Is(err, repository.ErrTakedownDecided):
+		web.WriteError(w, r, web.NewError(http.StatusConflict, web.CodeConflict, "O pedido já foi "+statusName(decided.Status)+"."))
+		return
+	case errors.Is(err, scheduler.ErrLocked):
+		web.WriteError(w, r, web.NewError(http.StatusConflict, web.CodeConflict, "Um scrape está em andamento, tente novamente em alguns minutos."))
+		return
+	case err != nil:
+		web.WriteError(w, r, web.Internal(fmt.Errorf("deciding takedown: %w", err)))
+		return
+	}
+	// The record may be in cached search responses, Approve marked the cache of the
+	// other instances to be purged and this one does not wait for the mark
+	if decided.Status == objects.TakedownApproved {
+		web.PurgeCache()
+	}
+	writeJSON(w, r, api.NewTakedown(decided))
+}
+
+func statusName(status string) string {
+	if status == objects.TakedownApproved {
+		return "aprovado"
+	}
+	return "rejeitado"
+}
@@ -0,0 +1,166 @@
+package web
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"os"
+	"refugio/objects"
+	"refugio/repository"
+	"refugio/utils/apikey"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+)
+
+const (
+	// Revoked or rotated keys stop working within keyCacheTTL, no restart needed
+	keyCacheTTL = time.Minute
+	// LastUsedAt is written at most once per touchInterval for each key
+	touchInterval = time.Minute
+)
+
+var (
+	legacyKeys    map[string]string
+	legacyKeysErr error
+	keyCache      = expirable.NewLRU[string, *objects.ApiKey](10000, nil, keyCacheTTL)
+	// Unknown ids are cached apart, so that made up ones cannot evict the keys in use
+	unknownKeyCache = expirable.NewLRU[string, struct{}](1000, nil, keyCacheTTL)
+
+	touchesMu sync.Mutex
+	touches   = map[string]time.Time{}
+)
+
+// LoadLegacyKeys reads the plaintext user/key pairs of AUTH_KEYS_FILE. These keys get
+// the search and sources scopes and keep working until every partner moves to keys
+// created with `app keys create`. Without a path there are no legacy keys.
+func LoadLegacyKeys(path string) error {
+	legacyKeysErr = loadLegacyKeys(path)
+	return legacyKeysErr
+}
+
+func loadLegacyKeys(path string) error {
+	if path == "" {
+		return nil
+	}
+	keyFile, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var keys map[string]string
+	if err := json.Unmarshal(keyFile, &keys); err != nil {
+		return err
+	}
+	legacyKeys = keys
+	return nil
+}
+
+// LegacyKeysLoaded returns the error of the last LoadLegacyKeys, if it failed.
+func LegacyKeysLoaded() error {
+	return legacyKeysErr
+}
+
+// KeyFromContext returns the key resolved by AuthMiddleware, if any.
+func KeyFromContext(r *http.Request) *objects.ApiKey {
+	key, _ := r.Context().Value(API_KEY_CONTEXT_KEY).(*objects.ApiKey)
+	return key
+}
+
+// WithKey adds the key to the context, as AuthMiddleware does.
+func WithKey(ctx context.Context, key *objects.ApiKey) context.Context {
+	return context.WithValue(ctx, API_KEY_CONTEXT_KEY, key)
+}
+
+// HasScope reports whether the key of the request has the given scope. Running
+// locally, every request has every scope.
+func HasScope(r *http.Request, scope string) bool {
+	if os.Getenv("ENVIRONMENT") == "local" {
+		return true
+	}
+	key := KeyFromContext(r)
+	return key != nil && key.HasScope(scope)
+}
+
+// RequireScope only lets through requests whose key has the given scope.
+func RequireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodOptions && !HasScope(r, scope) {
+				WriteError(w, r, Forbidden())
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// resolveKey returns the active key matching the token, or nil if there is none.
+func resolveKey(ctx context.Context, token string) (*objects.ApiKey, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	id, secret, ok := apikey.Parse(token)
+	if !ok {
+		return resolveLegacyKey(token), nil
+	}
+
+	if unknownKeyCache.Contains(id) {
+		return nil, nil
+	}
+	key, cached := keyCache.Get(id)
+	if !cached {
+		var err error
+		key, err = repository.FetchApiKeyFromFirestore(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if key == nil {
+			unknownKeyCache.Add(id, struct{}{})
+			return nil, nil
+		}
+		keyCache.Add(id, key)
+	}
+	if key == nil || !apikey.Verify(secret, key.Hash) || !key.IsActive(time.Now()) {
+		return nil, nil
+	}
+
+	touchKey(ctx, key)
+	return key, nil
+}
+
+// resolveLegacyKey goes through every legacy key, comparing hashes in constant time.
+func resolveLegacyKey(token string) *objects.ApiKey {
+	tokenHash := sha256.Sum256([]byte(token))
+	var match *objects.ApiKey
+	for user, validKey := range legacyKeys {
+		validHash := sha256.Sum256([]byte(validKey))
+		if subtle.ConstantTimeCompare(tokenHash[:], validHash[:]) == 1 {
+			match = &objects.ApiKey{
+				Id:      "legacy:" + user,
+				Name:    user,
+				Scopes:  []string{objects.ScopeSearch, objects.ScopeSources},
+				Enabled: true,
+			}
+		}
+	}
+	return match
+}
+
+func touchKey(ctx context.Context, key *objects.ApiKey) {
+	now := time.Now()
+	touchesMu.Lock()
+	last, ok := touches[key.Id]
+	if ok && now.Sub(last) < touchInterval {
+		touchesMu.Unlock()
+		return
+	}
+	touches[key.Id] = now
+	touchesMu.Unlock()
+
+	// The request may be over before the write is done
+	go repository.TouchApiKey(context.WithoutCancel(ctx), key.Id, now)
+}
@@ -1,32 +1,61 @@
 package web
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
+	"refugio/logging"
+	"refugio/metrics"
 	"refugio/objects"
+	"refugio/repository"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
-	"github.com/hashicorp/golang-lru/v2/expirable"
-
 	"cloud.google.com/go/compute/metadata"
-)
-
-var (
-	authKeys map[string]string
-	cache    *expirable.LRU[string, interface{}]
+	"github.com/gorilla/mux"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type contextKey string
 
 const (
 	ACCESS_LOG_CONTEXT_KEY contextKey = "access_log"
+	API_KEY_CONTEXT_KEY    contextKey = "api_key"
 )
 
+// statusRecorder remembers the status code written by the handlers.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusRecorder) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusRecorder) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// Unwrap lets http.ResponseController reach the flusher and the deadlines of the
+// underlying writer.
+func (w *statusRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
 func BaseRequestMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Basic headers
@@ -36,35 +65,82 @@ func BaseRequestMiddleware(next http.Handler) http.Handler {
 		if r.Method == http.MethodOptions {
 			return
 		}
-		log := &objects.AccessLog{}
-
-		ctx := context.WithValue(r.Context(), ACCESS_LOG_CONTEXT_KEY, log)
-		r = r.WithContext(ctx)
-		if os.Getenv("ENVIRONMENT") != "local" {
-			trace := getTrace(r)
-			userIp := r.Header.Get("X-Forwarded-For")
+		start := time.Now()
 
-			log.Trace = &trace
-			log.UserIP = &userIp
+		accessLog := &objects.AccessLog{RequestId: r.Header.Get("X-Request-Id")}
+		if accessLog.RequestId == "" {
+			accessLog.RequestId = newRequestId()
+		}
+		w.Header().Set("X-Request-Id", accessLog.RequestId)
+		if route := mux.CurrentRoute(r); route != nil {
+			accessLog.Route, _ = route.GetPathTemplate()
+		}
+		// The server span starts before routing, so it only gets its route here
+		span := trace.SpanFromContext(r.Context())
+		if accessLog.Route != "" {
+			span.SetName(r.Method + " " + accessLog.Route)
+			span.SetAttributes(semconv.HTTPRoute(accessLog.Route))
 		}
 
-		next.ServeHTTP(w, r)
-
-		accessLog := ctx.Value(ACCESS_LOG_CONTEXT_KEY).(*objects.AccessLog)
-		logJson, err := json.Marshal(accessLog)
+		ctx := context.WithValue(r.Context(), ACCESS_LOG_CONTEXT_KEY, accessLog)
+		ctx = logging.With(ctx, "request_id", accessLog.RequestId)
+		if os.Getenv("ENVIRONMENT") != "local" {
+			accessLog.Trace = getTrace(r)
+			accessLog.UserIP = r.Header.Get("X-Forwarded-For")
+			if accessLog.Trace != "" {
+				ctx = logging.With(ctx, logging.TraceKey, accessLog.Trace)
+			}
+		}
+		if spanContext := span.SpanContext(); spanContext.IsSampled() {
+			ctx = logging.With(ctx, logging.SpanIdKey, spanContext.SpanID().String(), logging.TraceSampledKey, true)
+		}
 
-		if *accessLog.Trace == "" {
-			return
+		recorder := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+		if recorder.status == 0 {
+			recorder.status = http.StatusOK
 		}
 
-		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-		} else {
-			fmt.Fprintln(os.Stdout, string(logJson))
+		latency := time.Since(start)
+		route := accessLog.Route
+		if route == "" {
+			route = "unmatched"
+		}
+		metrics.HTTPRequestDuration.WithLabelValues(route, r.Method, strconv.Itoa(recorder.status)).Observe(latency.Seconds())
+		level := slog.LevelInfo
+		if recorder.status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		attrs := []any{
+			slog.Group("httpRequest",
+				"requestMethod", r.Method,
+				"requestUrl", r.URL.RequestURI(),
+				"status", recorder.status,
+				"latency", fmt.Sprintf("%.6fs", latency.Seconds()),
+				"remoteIp", accessLog.UserIP,
+				"userAgent", r.UserAgent(),
+			),
+			"route", accessLog.Route,
+			"status", recorder.status,
+			"latency_ms", latency.Milliseconds(),
 		}
+		if accessLog.KeyUser != "" {
+			attrs = append(attrs, "key_user", accessLog.KeyUser)
+		}
+		if accessLog.ResultCount != nil {
+			attrs = append(attrs, "result_count", *accessLog.ResultCount)
+		}
+		slog.Log(ctx, level, "Request served", attrs...)
 	})
 }
 
+// SetResultCount records how many results the request returned, for the access log.
+func SetResultCount(ctx context.Context, count int) {
+	if accessLog, ok := ctx.Value(ACCESS_LOG_CONTEXT_KEY).(*objects.AccessLog); ok {
+		accessLog.ResultCount = &count
+	}
+}
+
 func AuthMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Preflight has no Authorization header
@@ -72,103 +148,57 @@ func AuthMiddleware(next http.Handler) http.Handler {
 			next.ServeHTTP(w, r)
 			return
 		}
-		key := r.Header.Get("Authorization")
-		// If key is valid, add user to AccessLog context
-		if user, authorized := isValidKey(key); !authorized {
-			http.Error(w, "Forbidden", http.StatusForbidden)
-			return
-		} else {
-			ctx := r.Context()
-			accessLog := ctx.Value(ACCESS_LOG_CONTEXT_KEY)
-			if accessLog != nil {
-				accessLog.(*objects.AccessLog).KeyUser = user
-			}
-			r = r.WithContext(ctx)
-		}
-		next.ServeHTTP(w, r)
-	})
-}
-
-/* Caching */
-type ResponseCapture struct {
-	http.ResponseWriter
-	Body bytes.Buffer
-}
-
-func (w *ResponseCapture) Write(data []byte) (int, error) {
-	w.Body.Write(data) // Capture the response body
-	return w.ResponseWriter.Write(data)
-}
-
-func CacheMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == http.MethodOptions {
+		// Prometheus and most HTTP clients send the key as a bearer token
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		key, err := resolveKey(r.Context(), token)
+		if err != nil {
+			WriteError(w, r, ServiceUnavailable(err))
 			return
 		}
-
-		nome := r.URL.Query().Get("nome")
-		var cacheKey string = nome
-		if cacheKey == "" {
-			cacheKey = r.URL.Path
-		}
-
-		if result, ok := cache.Get(cacheKey); ok {
-			jsonBytes, err := json.Marshal(result)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			w.Header().Set("Content-Type", "application/json")
-			w.Write(jsonBytes)
+		if key == nil {
+			WriteError(w, r, Forbidden())
 			return
 		}
 
-		capture := &ResponseCapture{ResponseWriter: w}
-		next.ServeHTTP(capture, r)
-
-		var result interface{}
-		if err := json.Unmarshal(capture.Body.Bytes(), &result); err == nil {
-			cache.Add(cacheKey, result)
-		} else {
-			fmt.Fprintf(os.Stderr, "Error unmarshalling response %v", err)
+		// Add the key and its user to the request context
+		ctx := WithKey(r.Context(), key)
+		if accessLog, ok := ctx.Value(ACCESS_LOG_CONTEXT_KEY).(*objects.AccessLog); ok {
+			accessLog.KeyUser = key.Name
 		}
+		ctx = logging.With(ctx, "key_user", key.Name)
+		ctx = repository.WithActor(ctx, objects.Actor{Kind: objects.ActorKey, Id: key.Id})
+		r = r.WithContext(ctx)
+		next.ServeHTTP(w, r)
 	})
 }
 
-func isValidKey(key string) (*string, bool) {
-	for validUser, validKey := range authKeys {
-		if key == validKey {
-			return &validUser, true
-		}
-	}
-	return nil, false
+func newRequestId() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
 }
 
-func init() {
-	keyFile, err := os.ReadFile(os.Getenv("AUTH_KEYS_FILE"))
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading auth keys file %v", err)
-	}
-
-	err = json.Unmarshal(keyFile, &authKeys)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error unmarshalling auth keys %v", err)
-	}
-
-	cache = expirable.NewLRU[string, interface{}](50000, nil, time.Minute*30)
-}
+// projectID is looked up once, the metadata server is slow to fail outside of GCP
+var projectID = sync.OnceValue(func() string {
+	id, _ := metadata.ProjectID()
+	return id
+})
 
+// getTrace builds the Cloud Logging trace of the request, from its span when there is
+// one, so that logs show up under the trace.
 func getTrace(r *http.Request) string {
 	// Trace logging for Cloud Run
-	projectID, _ := metadata.ProjectID()
-
-	var trace string
-	if projectID != "" {
-		traceHeader := r.Header.Get("X-Cloud-Trace-Context")
-		traceParts := strings.Split(traceHeader, "/")
-		if len(traceParts) > 0 && len(traceParts[0]) > 0 {
-			trace = fmt.Sprintf("projects/%s/traces/%s", projectID, traceParts[0])
-		}
+	projectID := projectID()
+
+	var traceId string
+	if spanContext := trace.SpanContextFromContext(r.Context()); spanContext.HasTraceID() {
+		traceId = spanContext.TraceID().String()
+	} else {
+		traceParts := strings.Split(r.Header.Get("X-Cloud-Trace-Context"), "/")
+		traceId = traceParts[0]
+	}
+	if projectID == "" || traceId == "" {
+		return ""
 	}
-	return trace
+	return fmt.Sprintf("projects/%s/traces/%s", projectID, traceId)
 }
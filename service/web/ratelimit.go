@@ -0,0 +1,202 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"os"
+	"refugio/objects"
+	"refugio/repository"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+)
+
+// RateLimitBackend keeps the token buckets. The memory backend is per instance, the
+// firestore one is shared by every instance at the cost of a transaction per request.
+type RateLimitBackend interface {
+	Take(ctx context.Context, bucket string, limit objects.RateLimit, now time.Time) (bool, objects.TokenBucket, error)
+}
+
+type memoryBackend struct {
+	mu      sync.Mutex
+	buckets *expirable.LRU[string, *objects.TokenBucket]
+}
+
+func (m *memoryBackend) Take(ctx context.Context, bucket string, limit objects.RateLimit, now time.Time) (bool, objects.TokenBucket, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state, ok := m.buckets.Get(bucket)
+	if !ok {
+		state = &objects.TokenBucket{}
+		m.buckets.Add(bucket, state)
+	}
+	taken := state.Take(limit, now)
+	return taken, *state, nil
+}
+
+type firestoreBackend struct{}
+
+func (firestoreBackend) Take(ctx context.Context, bucket string, limit objects.RateLimit, now time.Time) (bool, objects.TokenBucket, error) {
+	return repository.TakeToken(ctx, bucket, limit, now)
+}
+
+var rateLimitBackend RateLimitBackend = newMemoryBackend()
+
+// ipBackend keeps the buckets of IPRateLimitMiddleware
+var ipBackend RateLimitBackend = newMemoryBackend()
+
+func newMemoryBackend() *memoryBackend {
+	// Idle buckets are full again after an hour at most, dropping them changes nothing
+	return &memoryBackend{buckets: expirable.NewLRU[string, *objects.TokenBucket](100000, nil, time.Hour)}
+}
+
+// SetRateLimitBackend picks the backend by name, "memory" or "firestore".
+func SetRateLimitBackend(name string) error {
+	switch name {
+	case "", "memory":
+		rateLimitBackend = newMemoryBackend()
+	case "firestore":
+		rateLimitBackend = firestoreBackend{}
+	default:
+		return fmt.Errorf("unknown rate limit backend %q", name)
+	}
+	return nil
+}
+
+// RouteRateLimit reads the limit of a route from RATE_LIMIT_<ROUTE>, written as
+// "<requests per minute>/<burst>", falling back to the given default.
+func RouteRateLimit(route string, fallback objects.RateLimit) objects.RateLimit {
+	value := os.Getenv("RATE_LIMIT_" + strings.ToUpper(route))
+	if value == "" {
+		return fallback
+	}
+	perMinute, burst, _ := strings.Cut(value, "/")
+	limit := fallback
+	if n, err := strconv.Atoi(perMinute); err == nil {
+		limit.PerMinute = n
+		limit.Burst = n
+	}
+	if n, err := strconv.Atoi(burst); err == nil {
+		limit.Burst = n
+	}
+	return limit
+}
+
+// IPRateLimitMiddleware limits the requests of each client IP before they reach
+// AuthMiddleware, so that requests with a missing or made up key, which cost a lookup
+// of the key, are limited as well. The buckets are always kept in memory, as a shared
+// backend would cost a transaction per unauthenticated request.
+func IPRateLimitMiddleware(limit objects.RateLimit) func(http.Handler) http.Handler {
+	return rateLimit(ipBackend, false, func(r *http.Request) (string, objects.RateLimit) {
+		return "ip:" + clientIP(r), limit
+	})
+}
+
+// RateLimitMiddleware limits requests per key, or per client IP when there is no key.
+// A key's own RateLimit replaces the route limit.
+func RateLimitMiddleware(route string, routeLimit objects.RateLimit) func(http.Handler) http.Handler {
+	return rateLimit(nil, true, func(r *http.Request) (string, objects.RateLimit) {
+		if key := KeyFromContext(r); key != nil {
+			limit := routeLimit
+			if key.RateLimit != nil {
+				limit = *key.RateLimit
+			}
+			return "key:" + key.Id + ":" + route, limit
+		}
+		return "ip:" + clientIP(r) + ":" + route, routeLimit
+	})
+}
+
+// rateLimit takes a token from the bucket of each request, from the configured backend
+// when backend is nil. When the backend fails, the request goes through if failOpen is
+// set and is refused otherwise.
+func rateLimit(backend RateLimitBackend, failOpen bool, bucketOf func(r *http.Request) (string, objects.RateLimit)) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			bucket, limit := bucketOf(r)
+			if limit.PerMinute <= 0 || limit.Burst <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			b := backend
+			if b == nil {
+				b = rateLimitBackend
+			}
+			taken, state, err := b.Take(r.Context(), bucket, limit, time.Now())
+			if err != nil {
+				slog.ErrorContext(r.Context(), "Error checking rate limit", "bucket", bucket, "error", err)
+				// An outage of the key limits should not take the API down, but the IP limit
+				// is what keeps made up keys from reaching the key lookups
+				if failOpen {
+					next.ServeHTTP(w, r)
+				} else {
+					WriteError(w, r, ServiceUnavailable(err))
+				}
+				return
+			}
+
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(limit.Burst))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(int(state.Tokens)))
+			w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(state.Wait(limit, float64(limit.Burst)))))
+			w.Header().Set("RateLimit-Policy", fmt.Sprintf("%d;w=60;burst=%d", limit.PerMinute, limit.Burst))
+			if !taken {
+				retryAfter := ceilSeconds(state.Wait(limit, 1))
+				w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+				WriteError(w, r, NewError(http.StatusTooManyRequests, CodeRateLimited,
+					fmt.Sprintf("Muitas requisições. Tente novamente em %d segundos.", retryAfter)))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// trustedProxies is how many proxies in front of the server append to X-Forwarded-For,
+// read from TRUSTED_PROXIES. Cloud Run alone appends one address, a load balancer in
+// front of it another.
+var trustedProxies = sync.OnceValue(func() int {
+	if n, err := strconv.Atoi(os.Getenv("TRUSTED_PROXIES")); err == nil && n >= 0 {
+		return n
+	}
+	return 1
+})
+
+// clientIP is the address of X-Forwarded-For appended by the outermost trusted proxy, or
+// the peer address. The addresses before it are sent by the client, which can make them up.
+func clientIP(r *http.Request) string {
+	if hops := trustedProxies(); hops > 0 {
+		var addresses []string
+		for _, header := range r.Header.Values("X-Forwarded-For") {
+			for _, address := range strings.Split(header, ",") {
+				if address = strings.TrimSpace(address); address != "" {
+					addresses = append(addresses, address)
+				}
+			}
+		}
+		if len(addresses) > 0 {
+			return addresses[max(len(addresses)-hops, 0)]
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}